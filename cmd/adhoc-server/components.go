@@ -1,6 +1,7 @@
 package main
 
 import (
+	"youlingserv/internal/adhoc/dal"
 	"youlingserv/internal/adhoc/service"
 	"youlingserv/internal/shared/auth"
 )
@@ -8,16 +9,28 @@ import (
 // AdhocComponents 聚合 Adhoc 服务的所有组件
 type AdhocComponents struct {
 	ServiceImpl       service.AdhocServiceInterface
+	AdminService      service.AdminServiceInterface
+	Authenticator     auth.Authenticator
 	PermissionChecker *auth.PermissionChecker
+	MethodRules       *auth.MethodRules
+	AccessLogBroker   *dal.AccessLogBroker
 }
 
 // NewAdhocComponents 创建 Adhoc 组件聚合
 func NewAdhocComponents(
 	serviceImpl service.AdhocServiceInterface,
+	adminService service.AdminServiceInterface,
+	authenticator auth.Authenticator,
 	permissionChecker *auth.PermissionChecker,
+	methodRules *auth.MethodRules,
+	accessLogBroker *dal.AccessLogBroker,
 ) *AdhocComponents {
 	return &AdhocComponents{
 		ServiceImpl:       serviceImpl,
+		AdminService:      adminService,
+		Authenticator:     authenticator,
 		PermissionChecker: permissionChecker,
+		MethodRules:       methodRules,
+		AccessLogBroker:   accessLogBroker,
 	}
 }
@@ -1,18 +1,25 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
-	"net"
+	"os"
 
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
-	"gorm.io/gorm"
 
 	"youlingserv/internal/adhoc/routes"
 	grpcMiddleware "youlingserv/internal/shared/middleware/grpc"
+	"youlingserv/internal/shared/migrations"
 	"youlingserv/pkg/config"
-	"youlingserv/pkg/database"
+	"youlingserv/pkg/lifecycle"
 	"youlingserv/pkg/log"
+	"youlingserv/pkg/observability"
+	"youlingserv/pkg/tlsconfig"
 )
 
 func main() {
@@ -21,65 +28,126 @@ func main() {
 		panic(fmt.Sprintf("Failed to init config: %v", err))
 	}
 
-	// 初始化日志
+	// 按配置初始化日志，此前的日志使用默认配置输出
+	if err := observability.InitLogging(config.GetConfig().LogConf); err != nil {
+		panic(fmt.Sprintf("Failed to init logging: %v", err))
+	}
+
+	// migrate 子命令：执行数据库迁移后退出
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrations.Run(config.GetConfig(), os.Args[2:]); err != nil {
+			log.GetLogger().Error("Migration failed", zap.Error(err))
+			os.Exit(1)
+		}
+		return
+	}
+
 	log.GetLogger().Info("Adhoc gRPC Server starting...")
 
-	// 初始化数据库连接
-	// db, err := initDatabase()
-	// if err != nil {
-	// 	panic(fmt.Sprintf("Failed to connect to MySQL: %v", err))
-	// }
+	// 指标与链路追踪需在处理请求前初始化
+	observability.InitMetrics(config.GetConfig().MetricsConf.LatencyBuckets)
+	shutdownTracing, err := observability.InitTracing("adhoc-server", config.GetConfig().TracingConf)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to init tracing: %v", err))
+	}
 
-	// 使用 Wire 初始化所有依赖
-	components, err := InitializeAdhocService(nil)
+	// 使用 Wire 初始化所有依赖（含数据库连接，cleanup 负责关闭连接池）
+	components, cleanup, err := InitializeAdhocService(config.GetConfig())
 	if err != nil {
 		panic(fmt.Sprintf("Failed to initialize Adhoc service: %v", err))
 	}
 
 	// 创建并配置 gRPC 服务器
-	grpcServer := setupGRPCServer(components)
+	serverConf := config.GetConfig().GRPCServerConf
+	tlsConf, closeTLS, err := tlsconfig.NewServerConfig(serverConf.TLS)
+	if err != nil {
+		cleanup()
+		panic(fmt.Sprintf("Failed to load TLS config: %v", err))
+	}
+	grpcServer := setupGRPCServer(serverConf, tlsConf, components)
 
 	// 启用 gRPC 反射（用于 grpcurl 等工具）
 	reflection.Register(grpcServer)
 
 	// 注册服务
-	routes.RegisterAdhocRoutes(grpcServer, components.ServiceImpl)
+	routes.RegisterAdhocRoutes(grpcServer, components.ServiceImpl, components.AdminService)
 
-	// 启动服务器
-	if err := startServer(grpcServer); err != nil {
-		panic(fmt.Sprintf("Failed to serve: %v", err))
+	// 启动服务器，收到 SIGTERM/SIGINT 后按逆序优雅关闭
+	lc := lifecycle.NewManager(config.GetConfig().ShutdownConf.DrainTimeout)
+	lc.Append(lifecycle.LoggerHook(), lifecycle.TracingHook(shutdownTracing))
+	// 指标服务先启动、后关闭，优雅关闭期间仍可抓取
+	if serverConf.MetricsAddr != "" {
+		lc.Append(lifecycle.HTTPServerHook("metrics server", observability.NewMetricsServer(serverConf.MetricsAddr)))
 	}
+	lc.Append(
+		lifecycle.CleanupHook("adhoc components", cleanup),
+		lifecycle.CleanupHook("tls reloader", closeTLS),
+		lifecycle.GRPCServerHook(grpcServer, serverConf.Addr),
+		// 最后注册、最先关闭：先断开 WatchAccessLogs 长连接，GracefulStop 才能按时完成
+		lifecycle.CleanupHook("access log watchers", components.AccessLogBroker.Close),
+	)
+	if err := lc.Run(context.Background()); err != nil {
+		log.GetLogger().Error("Adhoc gRPC Server exited with error", zap.Error(err))
+		os.Exit(1)
+	}
+	log.GetLogger().Info("Adhoc gRPC Server stopped")
 }
 
-// initDatabase 初始化数据库连接
-func initDatabase() (*gorm.DB, error) {
-	return database.NewMySQLConnection(&database.MySQLConfig{
-		Host:     "localhost",
-		Port:     3306,
-		User:     "root",
-		Password: "password",
-		Database: "youlingserv",
-	})
-}
-
-// setupGRPCServer 配置 gRPC 服务器
-func setupGRPCServer(components *AdhocComponents) *grpc.Server {
-	return grpc.NewServer(
+// setupGRPCServer 配置 gRPC 服务器，tlsConf 为 nil 时使用明文
+func setupGRPCServer(conf config.GRPCServerConfig, tlsConf *tls.Config, components *AdhocComponents) *grpc.Server {
+	opts := append(grpcServerOptions(conf, tlsConf),
 		grpc.ChainUnaryInterceptor(
+			grpcMiddleware.RequestIDInterceptor(),
+			grpcMiddleware.TracingInterceptor(),
+			grpcMiddleware.LogContextInterceptor(),
 			grpcMiddleware.RecoveryInterceptor(),
 			grpcMiddleware.MetricsInterceptor(),
-			grpcMiddleware.AuthInterceptor(components.PermissionChecker),
+			grpcMiddleware.AuthInterceptor(components.Authenticator, components.PermissionChecker, components.MethodRules),
+		),
+		grpc.ChainStreamInterceptor(
+			grpcMiddleware.StreamRequestIDInterceptor(),
+			grpcMiddleware.StreamTracingInterceptor(),
+			grpcMiddleware.StreamLogContextInterceptor(),
+			grpcMiddleware.StreamRecoveryInterceptor(),
+			grpcMiddleware.StreamMetricsInterceptor(),
+			grpcMiddleware.StreamAuthInterceptor(components.Authenticator, components.PermissionChecker, components.MethodRules),
 		),
 	)
+	return grpc.NewServer(opts...)
 }
 
-// startServer 启动 gRPC 服务器
-func startServer(grpcServer *grpc.Server) error {
-	lis, err := net.Listen("tcp", ":50051")
-	if err != nil {
-		return fmt.Errorf("failed to listen: %w", err)
+// grpcServerOptions 根据配置生成服务器选项，零值项保持 gRPC 默认值
+func grpcServerOptions(conf config.GRPCServerConfig, tlsConf *tls.Config) []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if tlsConf != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConf)))
+	}
+	if conf.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(conf.MaxRecvMsgSize))
+	}
+	if conf.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(conf.MaxSendMsgSize))
+	}
+	if conf.ConnectionTimeout > 0 {
+		opts = append(opts, grpc.ConnectionTimeout(conf.ConnectionTimeout))
+	}
+	if conf.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(conf.MaxConcurrentStreams))
 	}
 
-	log.GetLogger().Info("Adhoc gRPC Server started on :50051")
-	return grpcServer.Serve(lis)
+	ka := conf.Keepalive
+	opts = append(opts,
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:                  ka.Time,
+			Timeout:               ka.Timeout,
+			MaxConnectionIdle:     ka.MaxConnectionIdle,
+			MaxConnectionAge:      ka.MaxConnectionAge,
+			MaxConnectionAgeGrace: ka.MaxConnectionAgeGrace,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             ka.MinTime,
+			PermitWithoutStream: ka.PermitWithoutStream,
+		}),
+	)
+	return opts
 }
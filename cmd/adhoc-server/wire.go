@@ -5,18 +5,29 @@ package main
 
 import (
 	"github.com/google/wire"
-	"gorm.io/gorm"
 
 	"youlingserv/internal/adhoc/biz"
 	"youlingserv/internal/adhoc/dal"
 	"youlingserv/internal/adhoc/service"
 	"youlingserv/internal/shared/auth"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/database"
 )
 
 // InitializeAdhocService 初始化 Adhoc 服务的所有依赖
-func InitializeAdhocService(db *gorm.DB) (*AdhocComponents, error) {
+func InitializeAdhocService(conf *config.Config) (*AdhocComponents, func(), error) {
 	wire.Build(
+		// 配置
+		wire.FieldsOf(new(*config.Config), "DBConf", "AccessLogConf", "AuthConf"),
+
+		// 数据库
+		database.NewConfig,
+		database.NewDB,
+		database.NewTxManager,
+
 		// DAL 层
+		dal.NewAccessLogBroker,
+		dal.NewAccessLogWriter,
 		dal.NewAdhocDAL,
 
 		// Biz 层
@@ -24,13 +35,18 @@ func InitializeAdhocService(db *gorm.DB) (*AdhocComponents, error) {
 
 		// Service 层
 		service.NewAdhocServiceImpl,
+		service.NewAdminServiceImpl,
 
 		// Auth
+		auth.NewAPIKeyStore,
+		wire.Bind(new(auth.APIKeyVerifier), new(*auth.APIKeyStore)),
+		auth.NewAuthenticator,
 		auth.NewAuthClient,
 		auth.NewPermissionChecker,
+		auth.NewMethodRules,
 
 		// 组件聚合
 		NewAdhocComponents,
 	)
-	return nil, nil
+	return nil, nil, nil
 }
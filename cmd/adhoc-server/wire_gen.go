@@ -11,19 +11,61 @@ import (
 	"youlingserv/internal/adhoc/dal"
 	"youlingserv/internal/adhoc/service"
 	"youlingserv/internal/shared/auth"
-
-	"gorm.io/gorm"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/database"
 )
 
 // Injectors from wire.go:
 
 // InitializeAdhocService 初始化 Adhoc 服务的所有依赖
-func InitializeAdhocService(db *gorm.DB) (*AdhocComponents, error) {
-	adhocDALInterface := dal.NewAdhocDAL(db)
-	adhocBizInterface := biz.NewAdhocBiz(adhocDALInterface)
+func InitializeAdhocService(conf *config.Config) (*AdhocComponents, func(), error) {
+	dbConfig := conf.DBConf
+	databaseConfig := database.NewConfig(dbConfig)
+	db, cleanup, err := database.NewDB(databaseConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	accessLogConfig := conf.AccessLogConf
+	v := dal.NewAccessLogBroker(accessLogConfig)
+	accessLogWriterInterface, cleanup2, err := dal.NewAccessLogWriter(db, v, accessLogConfig)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	adhocDALInterface := dal.NewAdhocDAL(db, accessLogWriterInterface, v)
+	txManager := database.NewTxManager(db, databaseConfig)
+	adhocBizInterface := biz.NewAdhocBiz(adhocDALInterface, txManager)
 	adhocServiceInterface := service.NewAdhocServiceImpl(adhocBizInterface)
-	authClient := auth.NewAuthClient()
+	adminServiceInterface := service.NewAdminServiceImpl()
+	authConfig := conf.AuthConf
+	apiKeyStore := auth.NewAPIKeyStore(db, authConfig)
+	authenticator, cleanup3, err := auth.NewAuthenticator(authConfig, apiKeyStore)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	authClient, cleanup4, err := auth.NewAuthClient(authConfig)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	permissionChecker := auth.NewPermissionChecker(authClient)
-	adhocComponents := NewAdhocComponents(adhocServiceInterface, permissionChecker)
-	return adhocComponents, nil
+	methodRules, err := auth.NewMethodRules(authConfig)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	adhocComponents := NewAdhocComponents(adhocServiceInterface, adminServiceInterface, authenticator, permissionChecker, methodRules, v)
+	return adhocComponents, func() {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"youlingserv/internal/api/gateway"
 	"youlingserv/internal/api/handler"
 	"youlingserv/internal/shared/auth"
 )
@@ -8,16 +9,31 @@ import (
 // APIComponents 聚合 API 服务的所有组件
 type APIComponents struct {
 	HelloHandler      handler.HelloHandlerInterface
+	APIKeyHandler     handler.APIKeyHandlerInterface
+	LogLevelHandler   handler.LogLevelHandlerInterface
+	Transcoder        *gateway.Transcoder
+	Authenticator     auth.Authenticator
 	PermissionChecker *auth.PermissionChecker
+	RouteRules        *auth.RouteRules
 }
 
 // NewAPIComponents 创建 API 组件聚合
 func NewAPIComponents(
 	helloHandler handler.HelloHandlerInterface,
+	apiKeyHandler handler.APIKeyHandlerInterface,
+	logLevelHandler handler.LogLevelHandlerInterface,
+	transcoder *gateway.Transcoder,
+	authenticator auth.Authenticator,
 	permissionChecker *auth.PermissionChecker,
+	routeRules *auth.RouteRules,
 ) *APIComponents {
 	return &APIComponents{
 		HelloHandler:      helloHandler,
+		APIKeyHandler:     apiKeyHandler,
+		LogLevelHandler:   logLevelHandler,
+		Transcoder:        transcoder,
+		Authenticator:     authenticator,
 		PermissionChecker: permissionChecker,
+		RouteRules:        routeRules,
 	}
 }
@@ -1,18 +1,25 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/cloudwego/hertz/pkg/app/server"
-	"gorm.io/gorm"
+	hertzconfig "github.com/cloudwego/hertz/pkg/common/config"
+	"go.uber.org/zap"
 
 	"youlingserv/internal/api/middleware"
 	"youlingserv/internal/api/routes"
 	httpMiddleware "youlingserv/internal/shared/middleware/http"
+	"youlingserv/internal/shared/migrations"
 	"youlingserv/pkg/config"
-	"youlingserv/pkg/database"
+	"youlingserv/pkg/lifecycle"
 	"youlingserv/pkg/log"
+	"youlingserv/pkg/observability"
+	"youlingserv/pkg/tlsconfig"
 )
 
 func main() {
@@ -21,17 +28,31 @@ func main() {
 		panic(fmt.Sprintf("Failed to init config: %v", err))
 	}
 
-	// 初始化日志
+	// 按配置初始化日志，此前的日志使用默认配置输出
+	if err := observability.InitLogging(config.GetConfig().LogConf); err != nil {
+		panic(fmt.Sprintf("Failed to init logging: %v", err))
+	}
+
+	// migrate 子命令：执行数据库迁移后退出
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrations.Run(config.GetConfig(), os.Args[2:]); err != nil {
+			log.GetLogger().Error("Migration failed", zap.Error(err))
+			os.Exit(1)
+		}
+		return
+	}
+
 	log.GetLogger().Info("API Gateway starting...")
 
-	// 初始化数据库连接
-	// db, err := initDatabase()
-	// if err != nil {
-	// 	panic(fmt.Sprintf("Failed to connect to MySQL: %v", err))
-	// }
+	// 指标与链路追踪需在处理请求前初始化
+	observability.InitMetrics(config.GetConfig().MetricsConf.LatencyBuckets)
+	shutdownTracing, err := observability.InitTracing("api-gateway", config.GetConfig().TracingConf)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to init tracing: %v", err))
+	}
 
-	// 使用 Wire 初始化所有依赖
-	components, err := InitializeAPIService(nil)
+	// 使用 Wire 初始化所有依赖（含数据库连接，cleanup 负责关闭连接池）
+	components, cleanup, err := InitializeAPIService(config.GetConfig())
 	if err != nil {
 		panic(fmt.Sprintf("Failed to initialize API service: %v", err))
 	}
@@ -40,39 +61,83 @@ func main() {
 	rateLimiter := middleware.NewRateLimiter(100, time.Minute)
 
 	// 创建并配置 HTTP 服务器
-	h := setupServer(components, rateLimiter)
-
-	// 启动服务器
-	log.GetLogger().Info("API Gateway started on :8080")
-	h.Spin()
-}
+	serverConf := config.GetConfig().HTTPServerConf
+	tlsConf, closeTLS, err := tlsconfig.NewServerConfig(serverConf.TLS)
+	if err != nil {
+		cleanup()
+		panic(fmt.Sprintf("Failed to load TLS config: %v", err))
+	}
+	h, err := setupServer(serverConf, tlsConf, components, rateLimiter)
+	if err != nil {
+		closeTLS()
+		cleanup()
+		panic(fmt.Sprintf("Failed to set up HTTP server: %v", err))
+	}
 
-// initDatabase 初始化数据库连接
-func initDatabase() (*gorm.DB, error) {
-	return database.NewMySQLConnection(&database.MySQLConfig{
-		Host:     "localhost",
-		Port:     3306,
-		User:     "root",
-		Password: "password",
-		Database: "youlingserv",
-	})
+	// 启动服务器，收到 SIGTERM/SIGINT 后按逆序优雅关闭：
+	// HTTP 服务器 → 下游连接等组件 → 指标服务 → 链路导出 → 日志
+	lc := lifecycle.NewManager(config.GetConfig().ShutdownConf.DrainTimeout)
+	lc.Append(lifecycle.LoggerHook(), lifecycle.TracingHook(shutdownTracing))
+	// 指标服务先启动、后关闭，优雅关闭期间仍可抓取
+	if serverConf.MetricsAddr != "" {
+		lc.Append(lifecycle.HTTPServerHook("metrics server", observability.NewMetricsServer(serverConf.MetricsAddr)))
+	}
+	lc.Append(
+		lifecycle.CleanupHook("api components", cleanup),
+		lifecycle.CleanupHook("tls reloader", closeTLS),
+		lifecycle.HertzHook(h),
+	)
+	log.GetLogger().Info("API Gateway started", zap.String("addr", serverConf.Addr))
+	if err := lc.Run(context.Background()); err != nil {
+		log.GetLogger().Error("API Gateway exited with error", zap.Error(err))
+		os.Exit(1)
+	}
+	log.GetLogger().Info("API Gateway stopped")
 }
 
-// setupServer 配置 HTTP 服务器
-func setupServer(components *APIComponents, rateLimiter *middleware.RateLimiter) *server.Hertz {
-	h := server.Default(
-		server.WithHostPorts("0.0.0.0:6789"),
-		server.WithMaxRequestBodySize(4*1024*1024), // 4MB
-	)
+// setupServer 配置 HTTP 服务器，tlsConf 为 nil 时使用明文
+func setupServer(conf config.HTTPServerConfig, tlsConf *tls.Config, components *APIComponents, rateLimiter *middleware.RateLimiter) (*server.Hertz, error) {
+	h := server.Default(httpServerOptions(conf, tlsConf)...)
 
 	// 注册全局中间件
+	h.Use(httpMiddleware.RequestIDMiddleware())
+	h.Use(httpMiddleware.TracingMiddleware())
+	h.Use(httpMiddleware.LogContextMiddleware())
 	h.Use(httpMiddleware.CORSMiddleware())
 	h.Use(httpMiddleware.MetricsMiddleware())
 	h.Use(rateLimiter.RateLimitMiddleware())
-	h.Use(httpMiddleware.AuthMiddleware(components.PermissionChecker))
+	h.Use(httpMiddleware.AuthMiddleware(components.Authenticator, components.PermissionChecker, components.RouteRules))
 
 	// 注册路由
-	routes.RegisterAPIRoutes(h, components.HelloHandler)
+	routes.RegisterAPIRoutes(h, components.HelloHandler, components.APIKeyHandler, components.LogLevelHandler)
+	if err := routes.RegisterGatewayRoutes(h, components.Transcoder, components.RouteRules); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
 
-	return h
+// httpServerOptions 根据配置生成 Hertz 选项，零值项保持 Hertz 默认值
+// 启用 TLS 时 Hertz 改用标准库网络层（netpoll 不支持 TLS）
+func httpServerOptions(conf config.HTTPServerConfig, tlsConf *tls.Config) []hertzconfig.Option {
+	opts := []hertzconfig.Option{
+		server.WithHostPorts(conf.Addr),
+		server.WithKeepAlive(conf.KeepAlive),
+	}
+	if tlsConf != nil {
+		opts = append(opts, server.WithTLS(tlsConf))
+	}
+	if conf.MaxRequestBodySize > 0 {
+		opts = append(opts, server.WithMaxRequestBodySize(conf.MaxRequestBodySize))
+	}
+	if conf.ReadTimeout > 0 {
+		opts = append(opts, server.WithReadTimeout(conf.ReadTimeout))
+	}
+	if conf.WriteTimeout > 0 {
+		opts = append(opts, server.WithWriteTimeout(conf.WriteTimeout))
+	}
+	if conf.IdleTimeout > 0 {
+		opts = append(opts, server.WithIdleTimeout(conf.IdleTimeout))
+	}
+	return opts
 }
@@ -5,32 +5,56 @@ package main
 
 import (
 	"github.com/google/wire"
-	"gorm.io/gorm"
 
 	"youlingserv/internal/api/biz"
+	"youlingserv/internal/api/client"
 	"youlingserv/internal/api/dal"
+	"youlingserv/internal/api/gateway"
 	"youlingserv/internal/api/handler"
 	"youlingserv/internal/shared/auth"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/database"
 )
 
 // InitializeAPIService 初始化 API 服务的所有依赖
-func InitializeAPIService(db *gorm.DB) (*APIComponents, error) {
+func InitializeAPIService(conf *config.Config) (*APIComponents, func(), error) {
 	wire.Build(
+		// 配置
+		wire.FieldsOf(new(*config.Config), "DBConf", "AdhocClientConf", "AuthConf"),
+
+		// 数据库
+		database.NewConfig,
+		database.NewDB,
+
 		// DAL 层
 		dal.NewUserDAL,
+		wire.Bind(new(dal.APIKeyDALInterface), new(*auth.APIKeyStore)),
 
 		// Biz 层
 		biz.NewHelloService,
+		biz.NewAPIKeyService,
+
+		// RPC 客户端
+		client.NewAdhocConn,
+
+		// REST → gRPC 转码
+		gateway.NewTranscoder,
 
 		// Handler 层
 		handler.NewHelloHandler,
+		handler.NewAPIKeyHandler,
+		handler.NewLogLevelHandler,
 
 		// Auth
+		auth.NewAPIKeyStore,
+		wire.Bind(new(auth.APIKeyVerifier), new(*auth.APIKeyStore)),
+		auth.NewAuthenticator,
 		auth.NewAuthClient,
 		auth.NewPermissionChecker,
+		auth.NewRouteRules,
 
 		// 组件聚合
 		NewAPIComponents,
 	)
-	return nil, nil
+	return nil, nil, nil
 }
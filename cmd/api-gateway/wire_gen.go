@@ -8,22 +8,72 @@ package main
 
 import (
 	"youlingserv/internal/api/biz"
+	"youlingserv/internal/api/client"
 	"youlingserv/internal/api/dal"
+	"youlingserv/internal/api/gateway"
 	"youlingserv/internal/api/handler"
 	"youlingserv/internal/shared/auth"
-
-	"gorm.io/gorm"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/database"
 )
 
 // Injectors from wire.go:
 
 // InitializeAPIService 初始化 API 服务的所有依赖
-func InitializeAPIService(db *gorm.DB) (*APIComponents, error) {
+func InitializeAPIService(conf *config.Config) (*APIComponents, func(), error) {
+	dbConfig := conf.DBConf
+	databaseConfig := database.NewConfig(dbConfig)
+	db, cleanup, err := database.NewDB(databaseConfig)
+	if err != nil {
+		return nil, nil, err
+	}
 	userDALInterface := dal.NewUserDAL(db)
 	helloServiceInterface := biz.NewHelloService(userDALInterface)
 	helloHandlerInterface := handler.NewHelloHandler(helloServiceInterface)
-	authClient := auth.NewAuthClient()
+	authConfig := conf.AuthConf
+	apiKeyStore := auth.NewAPIKeyStore(db, authConfig)
+	apiKeyServiceInterface := biz.NewAPIKeyService(apiKeyStore, authConfig)
+	apiKeyHandlerInterface := handler.NewAPIKeyHandler(apiKeyServiceInterface)
+	logLevelHandlerInterface := handler.NewLogLevelHandler()
+	adhocClientConfig := conf.AdhocClientConf
+	adhocConn, cleanup2, err := client.NewAdhocConn(adhocClientConfig)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	transcoder, err := gateway.NewTranscoder(adhocConn)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	authenticator, cleanup3, err := auth.NewAuthenticator(authConfig, apiKeyStore)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	authClient, cleanup4, err := auth.NewAuthClient(authConfig)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	permissionChecker := auth.NewPermissionChecker(authClient)
-	apiComponents := NewAPIComponents(helloHandlerInterface, permissionChecker)
-	return apiComponents, nil
+	routeRules, err := auth.NewRouteRules(authConfig)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	apiComponents := NewAPIComponents(helloHandlerInterface, apiKeyHandlerInterface, logLevelHandlerInterface, transcoder, authenticator, permissionChecker, routeRules)
+	return apiComponents, func() {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
 }
@@ -0,0 +1,43 @@
+// fake-permission-server 本地的 auth.v1.PermissionService，按 config.yml 中 auth.rbac 的策略文件判定
+// 配合 auth.remote.target 在没有真实权限服务的环境中联调
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"youlingserv/internal/shared/auth"
+	"youlingserv/internal/shared/auth/authtest"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/log"
+)
+
+func main() {
+	addr := flag.String("addr", ":50061", "listen address")
+	flag.Parse()
+
+	if err := config.InitLocalConfig(); err != nil {
+		panic(fmt.Sprintf("Failed to init config: %v", err))
+	}
+
+	rbac, err := auth.NewRBACClient(config.GetConfig().AuthConf.RBAC)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to load RBAC policy: %v", err))
+	}
+
+	listenAddr, stop, err := authtest.Serve(*addr, authtest.NewFakePermissionServer(rbac))
+	if err != nil {
+		panic(fmt.Sprintf("Failed to listen on %s: %v", *addr, err))
+	}
+	log.GetLogger().Info("Fake permission server listening", zap.String("addr", listenAddr))
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	stop()
+}
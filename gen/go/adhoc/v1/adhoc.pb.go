@@ -7,8 +7,10 @@
 package adhocv1
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +24,56 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SortOrder 访问日志按写入顺序排序的方向
+type SortOrder int32
+
+const (
+	SortOrder_SORT_ORDER_UNSPECIFIED SortOrder = 0 // 默认，同 DESC
+	SortOrder_SORT_ORDER_DESC        SortOrder = 1 // 最新的在前
+	SortOrder_SORT_ORDER_ASC         SortOrder = 2 // 最早的在前
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_DESC",
+		2: "SORT_ORDER_ASC",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED": 0,
+		"SORT_ORDER_DESC":        1,
+		"SORT_ORDER_ASC":         2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_adhoc_v1_adhoc_proto_enumTypes[0].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_adhoc_v1_adhoc_proto_enumTypes[0]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_adhoc_v1_adhoc_proto_rawDescGZIP(), []int{0}
+}
+
 type HelloRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -198,11 +250,551 @@ func (x *GoodbyeResponse) GetFarewell() string {
 	return ""
 }
 
+type AccessLog struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`                        // hello / goodbye
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // 访问时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessLog) Reset() {
+	*x = AccessLog{}
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessLog) ProtoMessage() {}
+
+func (x *AccessLog) ProtoReflect() protoreflect.Message {
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessLog.ProtoReflect.Descriptor instead.
+func (*AccessLog) Descriptor() ([]byte, []int) {
+	return file_adhoc_v1_adhoc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AccessLog) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AccessLog) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AccessLog) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AccessLog) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListAccessLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                            // 按名称精确过滤，空表示不过滤
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`                        // 按动作精确过滤，空表示不过滤
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // 起始时间（含）
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // 结束时间（不含）
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 每页条数，默认 50，最大 500
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // 上一页返回的 next_page_token
+	Order         SortOrder              `protobuf:"varint,7,opt,name=order,proto3,enum=adhoc.v1.SortOrder" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessLogsRequest) Reset() {
+	*x = ListAccessLogsRequest{}
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessLogsRequest) ProtoMessage() {}
+
+func (x *ListAccessLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessLogsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccessLogsRequest) Descriptor() ([]byte, []int) {
+	return file_adhoc_v1_adhoc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAccessLogsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListAccessLogsRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ListAccessLogsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ListAccessLogsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ListAccessLogsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAccessLogsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListAccessLogsRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+type ListAccessLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          []*AccessLog           `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // 为空表示没有下一页
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccessLogsResponse) Reset() {
+	*x = ListAccessLogsResponse{}
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessLogsResponse) ProtoMessage() {}
+
+func (x *ListAccessLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccessLogsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccessLogsResponse) Descriptor() ([]byte, []int) {
+	return file_adhoc_v1_adhoc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListAccessLogsResponse) GetLogs() []*AccessLog {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *ListAccessLogsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type WatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                       // 按名称过滤，空表示全部
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`                   // 按动作过滤，空表示全部
+	AfterId       int64                  `protobuf:"varint,3,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"` // 从该 ID 之后续传，0 表示只推送实时记录
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_adhoc_v1_adhoc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WatchRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetAfterId() int64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+type AccessLogEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Log           *AccessLog             `protobuf:"bytes,1,opt,name=log,proto3" json:"log,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessLogEvent) Reset() {
+	*x = AccessLogEvent{}
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessLogEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessLogEvent) ProtoMessage() {}
+
+func (x *AccessLogEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessLogEvent.ProtoReflect.Descriptor instead.
+func (*AccessLogEvent) Descriptor() ([]byte, []int) {
+	return file_adhoc_v1_adhoc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AccessLogEvent) GetLog() *AccessLog {
+	if x != nil {
+		return x.Log
+	}
+	return nil
+}
+
+type GetAccessStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                            // 按名称过滤，空表示全部
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // 起始时间（含）
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // 结束时间（不含）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccessStatsRequest) Reset() {
+	*x = GetAccessStatsRequest{}
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccessStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccessStatsRequest) ProtoMessage() {}
+
+func (x *GetAccessStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccessStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetAccessStatsRequest) Descriptor() ([]byte, []int) {
+	return file_adhoc_v1_adhoc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetAccessStatsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetAccessStatsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetAccessStatsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type ActionCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActionCount) Reset() {
+	*x = ActionCount{}
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionCount) ProtoMessage() {}
+
+func (x *ActionCount) ProtoReflect() protoreflect.Message {
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionCount.ProtoReflect.Descriptor instead.
+func (*ActionCount) Descriptor() ([]byte, []int) {
+	return file_adhoc_v1_adhoc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ActionCount) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ActionCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type DailyCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"` // YYYY-MM-DD，按数据库时区
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyCount) Reset() {
+	*x = DailyCount{}
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyCount) ProtoMessage() {}
+
+func (x *DailyCount) ProtoReflect() protoreflect.Message {
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyCount.ProtoReflect.Descriptor instead.
+func (*DailyCount) Descriptor() ([]byte, []int) {
+	return file_adhoc_v1_adhoc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DailyCount) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailyCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetAccessStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	ByAction      []*ActionCount         `protobuf:"bytes,2,rep,name=by_action,json=byAction,proto3" json:"by_action,omitempty"` // 按动作名升序
+	ByDay         []*DailyCount          `protobuf:"bytes,3,rep,name=by_day,json=byDay,proto3" json:"by_day,omitempty"`          // 按日期升序
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccessStatsResponse) Reset() {
+	*x = GetAccessStatsResponse{}
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccessStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccessStatsResponse) ProtoMessage() {}
+
+func (x *GetAccessStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adhoc_v1_adhoc_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccessStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetAccessStatsResponse) Descriptor() ([]byte, []int) {
+	return file_adhoc_v1_adhoc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetAccessStatsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetAccessStatsResponse) GetByAction() []*ActionCount {
+	if x != nil {
+		return x.ByAction
+	}
+	return nil
+}
+
+func (x *GetAccessStatsResponse) GetByDay() []*DailyCount {
+	if x != nil {
+		return x.ByDay
+	}
+	return nil
+}
+
 var File_adhoc_v1_adhoc_proto protoreflect.FileDescriptor
 
 const file_adhoc_v1_adhoc_proto_rawDesc = "" +
 	"\n" +
-	"\x14adhoc/v1/adhoc.proto\x12\badhoc.v1\x1a\x13common/common.proto\"\"\n" +
+	"\x14adhoc/v1/adhoc.proto\x12\badhoc.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13common/common.proto\x1a\x11common/auth.proto\"\"\n" +
 	"\fHelloRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\"+\n" +
 	"\rHelloResponse\x12\x1a\n" +
@@ -210,10 +802,63 @@ const file_adhoc_v1_adhoc_proto_rawDesc = "" +
 	"\x0eGoodbyeRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\"-\n" +
 	"\x0fGoodbyeResponse\x12\x1a\n" +
-	"\bfarewell\x18\x01 \x01(\tR\bfarewell2\x8a\x01\n" +
-	"\fAdhocService\x12:\n" +
-	"\x05Hello\x12\x16.adhoc.v1.HelloRequest\x1a\x17.adhoc.v1.HelloResponse\"\x00\x12>\n" +
-	"\aGoodbye\x12\x18.adhoc.v1.GoodbyeRequest\x1a\x19.adhoc.v1.GoodbyeResponseB%Z#youlingserv/gen/go/adhoc/v1;adhocv1b\x06proto3"
+	"\bfarewell\x18\x01 \x01(\tR\bfarewell\"\x82\x01\n" +
+	"\tAccessLog\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x9c\x02\n" +
+	"\x15ListAccessLogsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x12)\n" +
+	"\x05order\x18\a \x01(\x0e2\x13.adhoc.v1.SortOrderR\x05order\"i\n" +
+	"\x16ListAccessLogsResponse\x12'\n" +
+	"\x04logs\x18\x01 \x03(\v2\x13.adhoc.v1.AccessLogR\x04logs\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"U\n" +
+	"\fWatchRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x19\n" +
+	"\bafter_id\x18\x03 \x01(\x03R\aafterId\"7\n" +
+	"\x0eAccessLogEvent\x12%\n" +
+	"\x03log\x18\x01 \x01(\v2\x13.adhoc.v1.AccessLogR\x03log\"\x9d\x01\n" +
+	"\x15GetAccessStatsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\";\n" +
+	"\vActionCount\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"6\n" +
+	"\n" +
+	"DailyCount\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\x8f\x01\n" +
+	"\x16GetAccessStatsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x122\n" +
+	"\tby_action\x18\x02 \x03(\v2\x15.adhoc.v1.ActionCountR\bbyAction\x12+\n" +
+	"\x06by_day\x18\x03 \x03(\v2\x14.adhoc.v1.DailyCountR\x05byDay*P\n" +
+	"\tSortOrder\x12\x1a\n" +
+	"\x16SORT_ORDER_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fSORT_ORDER_DESC\x10\x01\x12\x12\n" +
+	"\x0eSORT_ORDER_ASC\x10\x022\x8a\x05\n" +
+	"\fAdhocService\x12~\n" +
+	"\x05Hello\x12\x16.adhoc.v1.HelloRequest\x1a\x17.adhoc.v1.HelloResponse\"D\xa2\xbb\x18\x18\n" +
+	"\x0fadhoc/greetings\x12\x05hello\x82\xd3\xe4\x93\x02\":\x01*Z\x12\x12\x10/v1/hello/{name}\"\t/v1/hello\x12t\n" +
+	"\aGoodbye\x12\x18.adhoc.v1.GoodbyeRequest\x1a\x19.adhoc.v1.GoodbyeResponse\"4\xa2\xbb\x18\x1a\n" +
+	"\x0fadhoc/greetings\x12\agoodbye\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/goodbye\x12\x89\x01\n" +
+	"\x0eListAccessLogs\x12\x1f.adhoc.v1.ListAccessLogsRequest\x1a .adhoc.v1.ListAccessLogsResponse\"4\xa2\xbb\x18\x19\n" +
+	"\x11adhoc/access-logs\x12\x04list\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/access-logs\x12e\n" +
+	"\x0fWatchAccessLogs\x12\x16.adhoc.v1.WatchRequest\x1a\x18.adhoc.v1.AccessLogEvent\"\x1e\xa2\xbb\x18\x1a\n" +
+	"\x11adhoc/access-logs\x12\x05watch0\x01\x12\x90\x01\n" +
+	"\x0eGetAccessStats\x12\x1f.adhoc.v1.GetAccessStatsRequest\x1a .adhoc.v1.GetAccessStatsResponse\";\xa2\xbb\x18\x1a\n" +
+	"\x11adhoc/access-logs\x12\x05stats\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/access-logs/statsB%Z#youlingserv/gen/go/adhoc/v1;adhocv1b\x06proto3"
 
 var (
 	file_adhoc_v1_adhoc_proto_rawDescOnce sync.Once
@@ -227,23 +872,51 @@ func file_adhoc_v1_adhoc_proto_rawDescGZIP() []byte {
 	return file_adhoc_v1_adhoc_proto_rawDescData
 }
 
-var file_adhoc_v1_adhoc_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_adhoc_v1_adhoc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_adhoc_v1_adhoc_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_adhoc_v1_adhoc_proto_goTypes = []any{
-	(*HelloRequest)(nil),    // 0: adhoc.v1.HelloRequest
-	(*HelloResponse)(nil),   // 1: adhoc.v1.HelloResponse
-	(*GoodbyeRequest)(nil),  // 2: adhoc.v1.GoodbyeRequest
-	(*GoodbyeResponse)(nil), // 3: adhoc.v1.GoodbyeResponse
+	(SortOrder)(0),                 // 0: adhoc.v1.SortOrder
+	(*HelloRequest)(nil),           // 1: adhoc.v1.HelloRequest
+	(*HelloResponse)(nil),          // 2: adhoc.v1.HelloResponse
+	(*GoodbyeRequest)(nil),         // 3: adhoc.v1.GoodbyeRequest
+	(*GoodbyeResponse)(nil),        // 4: adhoc.v1.GoodbyeResponse
+	(*AccessLog)(nil),              // 5: adhoc.v1.AccessLog
+	(*ListAccessLogsRequest)(nil),  // 6: adhoc.v1.ListAccessLogsRequest
+	(*ListAccessLogsResponse)(nil), // 7: adhoc.v1.ListAccessLogsResponse
+	(*WatchRequest)(nil),           // 8: adhoc.v1.WatchRequest
+	(*AccessLogEvent)(nil),         // 9: adhoc.v1.AccessLogEvent
+	(*GetAccessStatsRequest)(nil),  // 10: adhoc.v1.GetAccessStatsRequest
+	(*ActionCount)(nil),            // 11: adhoc.v1.ActionCount
+	(*DailyCount)(nil),             // 12: adhoc.v1.DailyCount
+	(*GetAccessStatsResponse)(nil), // 13: adhoc.v1.GetAccessStatsResponse
+	(*timestamppb.Timestamp)(nil),  // 14: google.protobuf.Timestamp
 }
 var file_adhoc_v1_adhoc_proto_depIdxs = []int32{
-	0, // 0: adhoc.v1.AdhocService.Hello:input_type -> adhoc.v1.HelloRequest
-	2, // 1: adhoc.v1.AdhocService.Goodbye:input_type -> adhoc.v1.GoodbyeRequest
-	1, // 2: adhoc.v1.AdhocService.Hello:output_type -> adhoc.v1.HelloResponse
-	3, // 3: adhoc.v1.AdhocService.Goodbye:output_type -> adhoc.v1.GoodbyeResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	14, // 0: adhoc.v1.AccessLog.created_at:type_name -> google.protobuf.Timestamp
+	14, // 1: adhoc.v1.ListAccessLogsRequest.start_time:type_name -> google.protobuf.Timestamp
+	14, // 2: adhoc.v1.ListAccessLogsRequest.end_time:type_name -> google.protobuf.Timestamp
+	0,  // 3: adhoc.v1.ListAccessLogsRequest.order:type_name -> adhoc.v1.SortOrder
+	5,  // 4: adhoc.v1.ListAccessLogsResponse.logs:type_name -> adhoc.v1.AccessLog
+	5,  // 5: adhoc.v1.AccessLogEvent.log:type_name -> adhoc.v1.AccessLog
+	14, // 6: adhoc.v1.GetAccessStatsRequest.start_time:type_name -> google.protobuf.Timestamp
+	14, // 7: adhoc.v1.GetAccessStatsRequest.end_time:type_name -> google.protobuf.Timestamp
+	11, // 8: adhoc.v1.GetAccessStatsResponse.by_action:type_name -> adhoc.v1.ActionCount
+	12, // 9: adhoc.v1.GetAccessStatsResponse.by_day:type_name -> adhoc.v1.DailyCount
+	1,  // 10: adhoc.v1.AdhocService.Hello:input_type -> adhoc.v1.HelloRequest
+	3,  // 11: adhoc.v1.AdhocService.Goodbye:input_type -> adhoc.v1.GoodbyeRequest
+	6,  // 12: adhoc.v1.AdhocService.ListAccessLogs:input_type -> adhoc.v1.ListAccessLogsRequest
+	8,  // 13: adhoc.v1.AdhocService.WatchAccessLogs:input_type -> adhoc.v1.WatchRequest
+	10, // 14: adhoc.v1.AdhocService.GetAccessStats:input_type -> adhoc.v1.GetAccessStatsRequest
+	2,  // 15: adhoc.v1.AdhocService.Hello:output_type -> adhoc.v1.HelloResponse
+	4,  // 16: adhoc.v1.AdhocService.Goodbye:output_type -> adhoc.v1.GoodbyeResponse
+	7,  // 17: adhoc.v1.AdhocService.ListAccessLogs:output_type -> adhoc.v1.ListAccessLogsResponse
+	9,  // 18: adhoc.v1.AdhocService.WatchAccessLogs:output_type -> adhoc.v1.AccessLogEvent
+	13, // 19: adhoc.v1.AdhocService.GetAccessStats:output_type -> adhoc.v1.GetAccessStatsResponse
+	15, // [15:20] is the sub-list for method output_type
+	10, // [10:15] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_adhoc_v1_adhoc_proto_init() }
@@ -256,13 +929,14 @@ func file_adhoc_v1_adhoc_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_adhoc_v1_adhoc_proto_rawDesc), len(file_adhoc_v1_adhoc_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_adhoc_v1_adhoc_proto_goTypes,
 		DependencyIndexes: file_adhoc_v1_adhoc_proto_depIdxs,
+		EnumInfos:         file_adhoc_v1_adhoc_proto_enumTypes,
 		MessageInfos:      file_adhoc_v1_adhoc_proto_msgTypes,
 	}.Build()
 	File_adhoc_v1_adhoc_proto = out.File
@@ -19,8 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AdhocService_Hello_FullMethodName   = "/adhoc.v1.AdhocService/Hello"
-	AdhocService_Goodbye_FullMethodName = "/adhoc.v1.AdhocService/Goodbye"
+	AdhocService_Hello_FullMethodName           = "/adhoc.v1.AdhocService/Hello"
+	AdhocService_Goodbye_FullMethodName         = "/adhoc.v1.AdhocService/Goodbye"
+	AdhocService_ListAccessLogs_FullMethodName  = "/adhoc.v1.AdhocService/ListAccessLogs"
+	AdhocService_WatchAccessLogs_FullMethodName = "/adhoc.v1.AdhocService/WatchAccessLogs"
+	AdhocService_GetAccessStats_FullMethodName  = "/adhoc.v1.AdhocService/GetAccessStats"
 )
 
 // AdhocServiceClient is the client API for AdhocService service.
@@ -29,6 +32,13 @@ const (
 type AdhocServiceClient interface {
 	Hello(ctx context.Context, in *HelloRequest, opts ...grpc.CallOption) (*HelloResponse, error)
 	Goodbye(ctx context.Context, in *GoodbyeRequest, opts ...grpc.CallOption) (*GoodbyeResponse, error)
+	// ListAccessLogs 按条件分页查询访问日志
+	ListAccessLogs(ctx context.Context, in *ListAccessLogsRequest, opts ...grpc.CallOption) (*ListAccessLogsResponse, error)
+	// WatchAccessLogs 实时推送新的访问日志
+	// 指定 after_id 时先补发该 ID 之后的历史记录再推送实时记录，用于断线续传
+	WatchAccessLogs(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AccessLogEvent], error)
+	// GetAccessStats 按动作和日期统计访问次数
+	GetAccessStats(ctx context.Context, in *GetAccessStatsRequest, opts ...grpc.CallOption) (*GetAccessStatsResponse, error)
 }
 
 type adhocServiceClient struct {
@@ -59,12 +69,58 @@ func (c *adhocServiceClient) Goodbye(ctx context.Context, in *GoodbyeRequest, op
 	return out, nil
 }
 
+func (c *adhocServiceClient) ListAccessLogs(ctx context.Context, in *ListAccessLogsRequest, opts ...grpc.CallOption) (*ListAccessLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccessLogsResponse)
+	err := c.cc.Invoke(ctx, AdhocService_ListAccessLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adhocServiceClient) WatchAccessLogs(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AccessLogEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AdhocService_ServiceDesc.Streams[0], AdhocService_WatchAccessLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, AccessLogEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AdhocService_WatchAccessLogsClient = grpc.ServerStreamingClient[AccessLogEvent]
+
+func (c *adhocServiceClient) GetAccessStats(ctx context.Context, in *GetAccessStatsRequest, opts ...grpc.CallOption) (*GetAccessStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAccessStatsResponse)
+	err := c.cc.Invoke(ctx, AdhocService_GetAccessStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdhocServiceServer is the server API for AdhocService service.
 // All implementations must embed UnimplementedAdhocServiceServer
 // for forward compatibility.
 type AdhocServiceServer interface {
 	Hello(context.Context, *HelloRequest) (*HelloResponse, error)
 	Goodbye(context.Context, *GoodbyeRequest) (*GoodbyeResponse, error)
+	// ListAccessLogs 按条件分页查询访问日志
+	ListAccessLogs(context.Context, *ListAccessLogsRequest) (*ListAccessLogsResponse, error)
+	// WatchAccessLogs 实时推送新的访问日志
+	// 指定 after_id 时先补发该 ID 之后的历史记录再推送实时记录，用于断线续传
+	WatchAccessLogs(*WatchRequest, grpc.ServerStreamingServer[AccessLogEvent]) error
+	// GetAccessStats 按动作和日期统计访问次数
+	GetAccessStats(context.Context, *GetAccessStatsRequest) (*GetAccessStatsResponse, error)
 	mustEmbedUnimplementedAdhocServiceServer()
 }
 
@@ -81,6 +137,15 @@ func (UnimplementedAdhocServiceServer) Hello(context.Context, *HelloRequest) (*H
 func (UnimplementedAdhocServiceServer) Goodbye(context.Context, *GoodbyeRequest) (*GoodbyeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Goodbye not implemented")
 }
+func (UnimplementedAdhocServiceServer) ListAccessLogs(context.Context, *ListAccessLogsRequest) (*ListAccessLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccessLogs not implemented")
+}
+func (UnimplementedAdhocServiceServer) WatchAccessLogs(*WatchRequest, grpc.ServerStreamingServer[AccessLogEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAccessLogs not implemented")
+}
+func (UnimplementedAdhocServiceServer) GetAccessStats(context.Context, *GetAccessStatsRequest) (*GetAccessStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccessStats not implemented")
+}
 func (UnimplementedAdhocServiceServer) mustEmbedUnimplementedAdhocServiceServer() {}
 func (UnimplementedAdhocServiceServer) testEmbeddedByValue()                      {}
 
@@ -138,6 +203,53 @@ func _AdhocService_Goodbye_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdhocService_ListAccessLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccessLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdhocServiceServer).ListAccessLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdhocService_ListAccessLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdhocServiceServer).ListAccessLogs(ctx, req.(*ListAccessLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdhocService_WatchAccessLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdhocServiceServer).WatchAccessLogs(m, &grpc.GenericServerStream[WatchRequest, AccessLogEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AdhocService_WatchAccessLogsServer = grpc.ServerStreamingServer[AccessLogEvent]
+
+func _AdhocService_GetAccessStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccessStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdhocServiceServer).GetAccessStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdhocService_GetAccessStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdhocServiceServer).GetAccessStats(ctx, req.(*GetAccessStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AdhocService_ServiceDesc is the grpc.ServiceDesc for AdhocService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,7 +265,21 @@ var AdhocService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Goodbye",
 			Handler:    _AdhocService_Goodbye_Handler,
 		},
+		{
+			MethodName: "ListAccessLogs",
+			Handler:    _AdhocService_ListAccessLogs_Handler,
+		},
+		{
+			MethodName: "GetAccessStats",
+			Handler:    _AdhocService_GetAccessStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAccessLogs",
+			Handler:       _AdhocService_WatchAccessLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "adhoc/v1/adhoc.proto",
 }
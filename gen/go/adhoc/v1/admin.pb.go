@@ -0,0 +1,217 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.28.1
+// source: adhoc/v1/admin.proto
+
+package adhocv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+	_ "youlingserv/gen/go/common"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetLogLevelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogLevelRequest) Reset() {
+	*x = GetLogLevelRequest{}
+	mi := &file_adhoc_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogLevelRequest) ProtoMessage() {}
+
+func (x *GetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adhoc_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*GetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_adhoc_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type SetLogLevelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"` // debug / info / warn / error
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_adhoc_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_adhoc_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_adhoc_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+type LogLevelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogLevelResponse) Reset() {
+	*x = LogLevelResponse{}
+	mi := &file_adhoc_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogLevelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLevelResponse) ProtoMessage() {}
+
+func (x *LogLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_adhoc_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLevelResponse.ProtoReflect.Descriptor instead.
+func (*LogLevelResponse) Descriptor() ([]byte, []int) {
+	return file_adhoc_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LogLevelResponse) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+var File_adhoc_v1_admin_proto protoreflect.FileDescriptor
+
+const file_adhoc_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x14adhoc/v1/admin.proto\x12\badhoc.v1\x1a\x11common/auth.proto\"\x14\n" +
+	"\x12GetLogLevelRequest\"*\n" +
+	"\x12SetLogLevelRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\"(\n" +
+	"\x10LogLevelResponse\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level2\xd4\x01\n" +
+	"\fAdminService\x12a\n" +
+	"\vGetLogLevel\x12\x1c.adhoc.v1.GetLogLevelRequest\x1a\x1a.adhoc.v1.LogLevelResponse\"\x18\xa2\xbb\x18\x14\n" +
+	"\rapi/log-level\x12\x03get\x12a\n" +
+	"\vSetLogLevel\x12\x1c.adhoc.v1.SetLogLevelRequest\x1a\x1a.adhoc.v1.LogLevelResponse\"\x18\xa2\xbb\x18\x14\n" +
+	"\rapi/log-level\x12\x03setB%Z#youlingserv/gen/go/adhoc/v1;adhocv1b\x06proto3"
+
+var (
+	file_adhoc_v1_admin_proto_rawDescOnce sync.Once
+	file_adhoc_v1_admin_proto_rawDescData []byte
+)
+
+func file_adhoc_v1_admin_proto_rawDescGZIP() []byte {
+	file_adhoc_v1_admin_proto_rawDescOnce.Do(func() {
+		file_adhoc_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_adhoc_v1_admin_proto_rawDesc), len(file_adhoc_v1_admin_proto_rawDesc)))
+	})
+	return file_adhoc_v1_admin_proto_rawDescData
+}
+
+var file_adhoc_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_adhoc_v1_admin_proto_goTypes = []any{
+	(*GetLogLevelRequest)(nil), // 0: adhoc.v1.GetLogLevelRequest
+	(*SetLogLevelRequest)(nil), // 1: adhoc.v1.SetLogLevelRequest
+	(*LogLevelResponse)(nil),   // 2: adhoc.v1.LogLevelResponse
+}
+var file_adhoc_v1_admin_proto_depIdxs = []int32{
+	0, // 0: adhoc.v1.AdminService.GetLogLevel:input_type -> adhoc.v1.GetLogLevelRequest
+	1, // 1: adhoc.v1.AdminService.SetLogLevel:input_type -> adhoc.v1.SetLogLevelRequest
+	2, // 2: adhoc.v1.AdminService.GetLogLevel:output_type -> adhoc.v1.LogLevelResponse
+	2, // 3: adhoc.v1.AdminService.SetLogLevel:output_type -> adhoc.v1.LogLevelResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_adhoc_v1_admin_proto_init() }
+func file_adhoc_v1_admin_proto_init() {
+	if File_adhoc_v1_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_adhoc_v1_admin_proto_rawDesc), len(file_adhoc_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_adhoc_v1_admin_proto_goTypes,
+		DependencyIndexes: file_adhoc_v1_admin_proto_depIdxs,
+		MessageInfos:      file_adhoc_v1_admin_proto_msgTypes,
+	}.Build()
+	File_adhoc_v1_admin_proto = out.File
+	file_adhoc_v1_admin_proto_goTypes = nil
+	file_adhoc_v1_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,165 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.28.1
+// source: common/auth.proto
+
+package common
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AuthRule RPC 的授权规则，通过 (common.auth) 方法选项声明
+// 没有声明规则的方法默认拒绝访问
+type AuthRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resource      string                 `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"` // 资源，如 adhoc/greetings
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`     // 动作，如 hello
+	Public        bool                   `protobuf:"varint,3,opt,name=public,proto3" json:"public,omitempty"`    // 公开方法，跳过认证与鉴权
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthRule) Reset() {
+	*x = AuthRule{}
+	mi := &file_common_auth_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthRule) ProtoMessage() {}
+
+func (x *AuthRule) ProtoReflect() protoreflect.Message {
+	mi := &file_common_auth_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthRule.ProtoReflect.Descriptor instead.
+func (*AuthRule) Descriptor() ([]byte, []int) {
+	return file_common_auth_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuthRule) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *AuthRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuthRule) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+var file_common_auth_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*AuthRule)(nil),
+		Field:         50100,
+		Name:          "common.auth",
+		Tag:           "bytes,50100,opt,name=auth",
+		Filename:      "common/auth.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional common.AuthRule auth = 50100;
+	E_Auth = &file_common_auth_proto_extTypes[0]
+)
+
+var File_common_auth_proto protoreflect.FileDescriptor
+
+const file_common_auth_proto_rawDesc = "" +
+	"\n" +
+	"\x11common/auth.proto\x12\x06common\x1a google/protobuf/descriptor.proto\"V\n" +
+	"\bAuthRule\x12\x1a\n" +
+	"\bresource\x18\x01 \x01(\tR\bresource\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x16\n" +
+	"\x06public\x18\x03 \x01(\bR\x06public:F\n" +
+	"\x04auth\x12\x1e.google.protobuf.MethodOptions\x18\xb4\x87\x03 \x01(\v2\x10.common.AuthRuleR\x04authB\"Z youlingserv/gen/go/common;commonb\x06proto3"
+
+var (
+	file_common_auth_proto_rawDescOnce sync.Once
+	file_common_auth_proto_rawDescData []byte
+)
+
+func file_common_auth_proto_rawDescGZIP() []byte {
+	file_common_auth_proto_rawDescOnce.Do(func() {
+		file_common_auth_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_common_auth_proto_rawDesc), len(file_common_auth_proto_rawDesc)))
+	})
+	return file_common_auth_proto_rawDescData
+}
+
+var file_common_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_common_auth_proto_goTypes = []any{
+	(*AuthRule)(nil),                   // 0: common.AuthRule
+	(*descriptorpb.MethodOptions)(nil), // 1: google.protobuf.MethodOptions
+}
+var file_common_auth_proto_depIdxs = []int32{
+	1, // 0: common.auth:extendee -> google.protobuf.MethodOptions
+	0, // 1: common.auth:type_name -> common.AuthRule
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_common_auth_proto_init() }
+func file_common_auth_proto_init() {
+	if File_common_auth_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_auth_proto_rawDesc), len(file_common_auth_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_common_auth_proto_goTypes,
+		DependencyIndexes: file_common_auth_proto_depIdxs,
+		MessageInfos:      file_common_auth_proto_msgTypes,
+		ExtensionInfos:    file_common_auth_proto_extTypes,
+	}.Build()
+	File_common_auth_proto = out.File
+	file_common_auth_proto_goTypes = nil
+	file_common_auth_proto_depIdxs = nil
+}
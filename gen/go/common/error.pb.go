@@ -24,13 +24,15 @@ const (
 type ErrorCode int32
 
 const (
-	ErrorCode_UNKNOWN           ErrorCode = 0 // 未知错误
-	ErrorCode_INVALID_ARGUMENT  ErrorCode = 1 // 无效参数
-	ErrorCode_NOT_FOUND         ErrorCode = 2 // 未找到
-	ErrorCode_ALREADY_EXISTS    ErrorCode = 3 // 已存在
-	ErrorCode_PERMISSION_DENIED ErrorCode = 4 // 权限拒绝
-	ErrorCode_UNAUTHENTICATED   ErrorCode = 5 // 未认证
-	ErrorCode_INTERNAL_ERROR    ErrorCode = 6 // 内部错误
+	ErrorCode_UNKNOWN            ErrorCode = 0 // 未知错误
+	ErrorCode_INVALID_ARGUMENT   ErrorCode = 1 // 无效参数
+	ErrorCode_NOT_FOUND          ErrorCode = 2 // 未找到
+	ErrorCode_ALREADY_EXISTS     ErrorCode = 3 // 已存在
+	ErrorCode_PERMISSION_DENIED  ErrorCode = 4 // 权限拒绝
+	ErrorCode_UNAUTHENTICATED    ErrorCode = 5 // 未认证
+	ErrorCode_INTERNAL_ERROR     ErrorCode = 6 // 内部错误
+	ErrorCode_RESOURCE_EXHAUSTED ErrorCode = 7 // 资源耗尽（队列满、限流等）
+	ErrorCode_UNAVAILABLE        ErrorCode = 8 // 服务暂不可用，可稍后重试
 )
 
 // Enum value maps for ErrorCode.
@@ -43,15 +45,19 @@ var (
 		4: "PERMISSION_DENIED",
 		5: "UNAUTHENTICATED",
 		6: "INTERNAL_ERROR",
+		7: "RESOURCE_EXHAUSTED",
+		8: "UNAVAILABLE",
 	}
 	ErrorCode_value = map[string]int32{
-		"UNKNOWN":           0,
-		"INVALID_ARGUMENT":  1,
-		"NOT_FOUND":         2,
-		"ALREADY_EXISTS":    3,
-		"PERMISSION_DENIED": 4,
-		"UNAUTHENTICATED":   5,
-		"INTERNAL_ERROR":    6,
+		"UNKNOWN":            0,
+		"INVALID_ARGUMENT":   1,
+		"NOT_FOUND":          2,
+		"ALREADY_EXISTS":     3,
+		"PERMISSION_DENIED":  4,
+		"UNAUTHENTICATED":    5,
+		"INTERNAL_ERROR":     6,
+		"RESOURCE_EXHAUSTED": 7,
+		"UNAVAILABLE":        8,
 	}
 )
 
@@ -82,11 +88,67 @@ func (ErrorCode) EnumDescriptor() ([]byte, []int) {
 	return file_common_error_proto_rawDescGZIP(), []int{0}
 }
 
+// ErrorDetail 附加在 gRPC status details 中的业务错误码
+type ErrorDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          ErrorCode              `protobuf:"varint,1,opt,name=code,proto3,enum=common.ErrorCode" json:"code,omitempty"` // 业务错误码
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                  // 面向调用方的错误描述
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorDetail) Reset() {
+	*x = ErrorDetail{}
+	mi := &file_common_error_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorDetail) ProtoMessage() {}
+
+func (x *ErrorDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_common_error_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorDetail.ProtoReflect.Descriptor instead.
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return file_common_error_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ErrorDetail) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_UNKNOWN
+}
+
+func (x *ErrorDetail) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_common_error_proto protoreflect.FileDescriptor
 
 const file_common_error_proto_rawDesc = "" +
 	"\n" +
-	"\x12common/error.proto\x12\x06common*\x91\x01\n" +
+	"\x12common/error.proto\x12\x06common\"N\n" +
+	"\vErrorDetail\x12%\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage*\xba\x01\n" +
 	"\tErrorCode\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\x14\n" +
 	"\x10INVALID_ARGUMENT\x10\x01\x12\r\n" +
@@ -94,7 +156,9 @@ const file_common_error_proto_rawDesc = "" +
 	"\x0eALREADY_EXISTS\x10\x03\x12\x15\n" +
 	"\x11PERMISSION_DENIED\x10\x04\x12\x13\n" +
 	"\x0fUNAUTHENTICATED\x10\x05\x12\x12\n" +
-	"\x0eINTERNAL_ERROR\x10\x06B\"Z youlingserv/gen/go/common;commonb\x06proto3"
+	"\x0eINTERNAL_ERROR\x10\x06\x12\x16\n" +
+	"\x12RESOURCE_EXHAUSTED\x10\a\x12\x0f\n" +
+	"\vUNAVAILABLE\x10\bB\"Z youlingserv/gen/go/common;commonb\x06proto3"
 
 var (
 	file_common_error_proto_rawDescOnce sync.Once
@@ -109,15 +173,18 @@ func file_common_error_proto_rawDescGZIP() []byte {
 }
 
 var file_common_error_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_common_error_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_common_error_proto_goTypes = []any{
-	(ErrorCode)(0), // 0: common.ErrorCode
+	(ErrorCode)(0),      // 0: common.ErrorCode
+	(*ErrorDetail)(nil), // 1: common.ErrorDetail
 }
 var file_common_error_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: common.ErrorDetail.code:type_name -> common.ErrorCode
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_common_error_proto_init() }
@@ -131,13 +198,14 @@ func file_common_error_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_common_error_proto_rawDesc), len(file_common_error_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   0,
+			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_common_error_proto_goTypes,
 		DependencyIndexes: file_common_error_proto_depIdxs,
 		EnumInfos:         file_common_error_proto_enumTypes,
+		MessageInfos:      file_common_error_proto_msgTypes,
 	}.Build()
 	File_common_error_proto = out.File
 	file_common_error_proto_goTypes = nil
@@ -0,0 +1,42 @@
+package biz
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"youlingserv/internal/adhoc/dal"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// AccessLogQuery 访问日志分页查询参数
+type AccessLogQuery struct {
+	Filter    dal.AccessLogFilter
+	PageSize  int    // 0 使用默认值，超过上限时按上限处理
+	PageToken string // 上一页返回的游标
+	Desc      bool   // 最新的在前
+}
+
+// pageToken 游标内容，对调用方不透明
+type pageToken struct {
+	AfterID int64 `json:"after_id"`
+	Desc    bool  `json:"desc"`
+}
+
+func encodePageToken(t pageToken) string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(s string) (pageToken, error) {
+	var t pageToken
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return t, err
+	}
+	err = json.Unmarshal(data, &t)
+	return t, err
+}
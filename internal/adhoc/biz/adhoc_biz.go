@@ -2,40 +2,240 @@ package biz
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
+	"go.uber.org/zap"
+
+	"youlingserv/gen/go/common"
 	"youlingserv/internal/adhoc/dal"
+	"youlingserv/internal/adhoc/dal/model"
+	"youlingserv/internal/shared/errcode"
+	"youlingserv/pkg/database"
 	"youlingserv/pkg/log"
+	"youlingserv/pkg/pubsub"
+)
+
+const (
+	// maxNameLength 与 adhoc_users / adhoc_access_logs 的 name 列宽一致
+	maxNameLength = 50
+	// maxWatchBackfill WatchAccessLogs 续传时最多补发的记录数
+	maxWatchBackfill = 10000
 )
 
 type AdhocBiz struct {
-	adhocDAL dal.AdhocDALInterface
+	adhocDAL  dal.AdhocDALInterface
+	txManager database.TxManager
 }
 
-func NewAdhocBiz(adhocDAL dal.AdhocDALInterface) AdhocBizInterface {
+func NewAdhocBiz(adhocDAL dal.AdhocDALInterface, txManager database.TxManager) AdhocBizInterface {
 	return &AdhocBiz{
-		adhocDAL: adhocDAL,
+		adhocDAL:  adhocDAL,
+		txManager: txManager,
 	}
 }
 
 func (b *AdhocBiz) ProcessHello(ctx context.Context, name string) (string, error) {
-	log.GetLogger().Info(fmt.Sprintf("ProcessHello: name=%s", name))
+	log.FromContext(ctx).Info("ProcessHello", zap.String("name", name))
 
-	err := b.adhocDAL.RecordAccess(ctx, name, "hello")
-	if err != nil {
+	if err := validateName(name); err != nil {
 		return "", err
 	}
 
+	// 用户访问计数在事务中更新；访问日志在提交后交给异步写入器，与 Goodbye 一样批量写入并受溢出策略约束
+	var recordErr error
+	err := b.txManager.WithinTx(ctx, func(txCtx context.Context) error {
+		if err := b.adhocDAL.TouchUser(txCtx, name); err != nil {
+			return err
+		}
+		// 使用事务外的 ctx，RecordAccess 才会走异步写入器而不是在事务中同步写入
+		database.AfterCommit(txCtx, func() { recordErr = b.adhocDAL.RecordAccess(ctx, name, "hello") })
+		return nil
+	})
+	if err == nil {
+		err = recordErr
+	}
+	if err != nil {
+		return "", wrapDALError(err)
+	}
+
 	return fmt.Sprintf("Hello from Adhoc service, %s!", name), nil
 }
 
 func (b *AdhocBiz) ProcessGoodbye(ctx context.Context, name string) (string, error) {
-	log.GetLogger().Info(fmt.Sprintf("ProcessGoodbye: name=%s", name))
+	log.FromContext(ctx).Info("ProcessGoodbye", zap.String("name", name))
+
+	if err := validateName(name); err != nil {
+		return "", err
+	}
 
 	err := b.adhocDAL.RecordAccess(ctx, name, "goodbye")
 	if err != nil {
-		return "", err
+		return "", wrapDALError(err)
 	}
 
 	return fmt.Sprintf("Goodbye, %s! See you next time!", name), nil
 }
+
+// ListAccessLogs 分页查询访问日志，返回下一页的游标，没有更多数据时为空
+func (b *AdhocBiz) ListAccessLogs(ctx context.Context, query AccessLogQuery) ([]*model.AdhocAccessLog, string, error) {
+	if err := validateFilter(query.Filter); err != nil {
+		return nil, "", err
+	}
+
+	pageSize := query.PageSize
+	switch {
+	case pageSize < 0:
+		return nil, "", errcode.New(common.ErrorCode_INVALID_ARGUMENT, "page_size must not be negative")
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+
+	page := dal.AccessLogPage{Desc: query.Desc, Limit: pageSize + 1}
+	if query.PageToken != "" {
+		cursor, err := decodePageToken(query.PageToken)
+		if err != nil || cursor.Desc != query.Desc {
+			return nil, "", errcode.New(common.ErrorCode_INVALID_ARGUMENT, "invalid page_token")
+		}
+		page.AfterID = cursor.AfterID
+	}
+
+	logs, err := b.adhocDAL.ListAccessLogs(ctx, query.Filter, page)
+	if err != nil {
+		return nil, "", wrapQueryError(err)
+	}
+
+	// 多取一条判断是否还有下一页
+	var next string
+	if len(logs) > pageSize {
+		logs = logs[:pageSize]
+		next = encodePageToken(pageToken{AfterID: logs[len(logs)-1].ID, Desc: query.Desc})
+	}
+	return logs, next, nil
+}
+
+// WatchAccessLogs 把符合条件的新访问日志逐条交给 send，直到 ctx 结束或 send 失败
+// afterID > 0 时先补发该 ID 之后已落库的记录；先订阅再补发，补发期间的实时记录按 ID 去重
+func (b *AdhocBiz) WatchAccessLogs(ctx context.Context, filter dal.AccessLogFilter, afterID int64, send func(*model.AdhocAccessLog) error) error {
+	if afterID < 0 {
+		return errcode.New(common.ErrorCode_INVALID_ARGUMENT, "after_id must not be negative")
+	}
+
+	sub := b.adhocDAL.SubscribeAccessLogs()
+	defer sub.Close()
+
+	var backfilled map[int64]struct{}
+	if afterID > 0 {
+		var err error
+		if backfilled, err = b.backfillAccessLogs(ctx, filter, afterID, send); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case record, ok := <-sub.C():
+			if !ok {
+				if errors.Is(sub.Err(), pubsub.ErrSlowConsumer) {
+					return errcode.New(common.ErrorCode_RESOURCE_EXHAUSTED, "watcher is too slow, resume with after_id")
+				}
+				return errcode.Wrap(common.ErrorCode_UNAVAILABLE, sub.Err(), "server is shutting down, resume with after_id")
+			}
+			if _, ok := backfilled[record.ID]; ok || !matchFilter(record, filter) {
+				continue
+			}
+			if err := send(record); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// backfillAccessLogs 从主库补发 afterID 之后的记录，返回已发送的 ID
+func (b *AdhocBiz) backfillAccessLogs(ctx context.Context, filter dal.AccessLogFilter, afterID int64, send func(*model.AdhocAccessLog) error) (map[int64]struct{}, error) {
+	// 补发读主库，避免副本延迟造成遗漏
+	ctx = database.WithPrimary(ctx)
+	sent := make(map[int64]struct{})
+	for {
+		logs, err := b.adhocDAL.ListAccessLogs(ctx, filter, dal.AccessLogPage{AfterID: afterID, Limit: maxPageSize})
+		if err != nil {
+			return nil, wrapQueryError(err)
+		}
+		for _, l := range logs {
+			if len(sent) >= maxWatchBackfill {
+				// 已发送的记录有效，调用方可从最后一条继续续传，属于可重试的情况而非参数错误
+				return nil, errcode.New(common.ErrorCode_RESOURCE_EXHAUSTED,
+					fmt.Sprintf("backfill limit of %d records reached, resume with after_id=%d or catch up with ListAccessLogs", maxWatchBackfill, afterID))
+			}
+			if err := send(l); err != nil {
+				return nil, err
+			}
+			sent[l.ID] = struct{}{}
+			afterID = l.ID
+		}
+		if len(logs) < maxPageSize {
+			return sent, nil
+		}
+	}
+}
+
+// GetAccessStats 按动作和日期统计访问次数
+func (b *AdhocBiz) GetAccessStats(ctx context.Context, filter dal.AccessLogFilter) (*dal.AccessStats, error) {
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
+
+	stats, err := b.adhocDAL.GetAccessStats(ctx, filter)
+	if err != nil {
+		return nil, wrapQueryError(err)
+	}
+	return stats, nil
+}
+
+// validateName 名称必填，且不能超过 adhoc_users.name 的列宽
+func validateName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errcode.New(common.ErrorCode_INVALID_ARGUMENT, "name is required")
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return errcode.New(common.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("name must be at most %d characters", maxNameLength))
+	}
+	return nil
+}
+
+// wrapDALError 为 DAL 错误附加业务错误码，ctx 取消 / 超时保持原样交给 service 层处理
+func wrapDALError(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return err
+	case errors.Is(err, dal.ErrAccessLogQueueFull):
+		return errcode.Wrap(common.ErrorCode_RESOURCE_EXHAUSTED, err, "too many requests, please retry later")
+	default:
+		return errcode.Wrap(common.ErrorCode_INTERNAL_ERROR, err, "failed to record access")
+	}
+}
+
+func validateFilter(filter dal.AccessLogFilter) error {
+	if !filter.Start.IsZero() && !filter.End.IsZero() && !filter.Start.Before(filter.End) {
+		return errcode.New(common.ErrorCode_INVALID_ARGUMENT, "start_time must be before end_time")
+	}
+	return nil
+}
+
+func wrapQueryError(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return errcode.Wrap(common.ErrorCode_INTERNAL_ERROR, err, "failed to query access logs")
+}
+
+func matchFilter(record *model.AdhocAccessLog, filter dal.AccessLogFilter) bool {
+	return (filter.Name == "" || record.Name == filter.Name) &&
+		(filter.Action == "" || record.Action == filter.Action)
+}
@@ -1,11 +1,19 @@
 package biz
 
-import "context"
+import (
+	"context"
+
+	"youlingserv/internal/adhoc/dal"
+	"youlingserv/internal/adhoc/dal/model"
+)
 
 // AdhocBizInterface Adhoc 业务逻辑接口
 type AdhocBizInterface interface {
 	ProcessHello(ctx context.Context, name string) (string, error)
 	ProcessGoodbye(ctx context.Context, name string) (string, error)
+	ListAccessLogs(ctx context.Context, query AccessLogQuery) ([]*model.AdhocAccessLog, string, error)
+	GetAccessStats(ctx context.Context, filter dal.AccessLogFilter) (*dal.AccessStats, error)
+	WatchAccessLogs(ctx context.Context, filter dal.AccessLogFilter, afterID int64, send func(*model.AdhocAccessLog) error) error
 }
 
 // Ensure AdhocBiz implements AdhocBizInterface
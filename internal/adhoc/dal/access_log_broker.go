@@ -0,0 +1,18 @@
+package dal
+
+import (
+	"youlingserv/internal/adhoc/dal/model"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/pubsub"
+)
+
+// AccessLogBroker 广播已落库的访问日志，供 WatchAccessLogs 实时推送
+type AccessLogBroker = pubsub.Broker[*model.AdhocAccessLog]
+
+// AccessLogSubscription 访问日志订阅
+type AccessLogSubscription = pubsub.Subscription[*model.AdhocAccessLog]
+
+// NewAccessLogBroker 创建访问日志广播器，关闭时断开所有订阅者
+func NewAccessLogBroker(conf config.AccessLogConfig) *AccessLogBroker {
+	return pubsub.NewBroker[*model.AdhocAccessLog](conf.WatchBuffer)
+}
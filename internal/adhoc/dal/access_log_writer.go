@@ -0,0 +1,244 @@
+package dal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youlingserv/internal/adhoc/dal/model"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/log"
+	"youlingserv/pkg/observability"
+)
+
+// 队列满时的处理策略
+const (
+	OverflowBlock      = "block"       // 阻塞等待，直到有空位或请求 ctx 结束
+	OverflowDropOldest = "drop_oldest" // 丢弃队列中最早的一条
+	OverflowError      = "error"       // 直接返回 ErrAccessLogQueueFull
+)
+
+const (
+	defaultAccessLogQueueSize     = 10000
+	defaultAccessLogBatchSize     = 200
+	defaultAccessLogFlushInterval = time.Second
+	accessLogFlushTimeout         = 10 * time.Second
+)
+
+var (
+	ErrAccessLogQueueFull    = errors.New("access log queue is full")
+	ErrAccessLogWriterClosed = errors.New("access log writer is closed")
+)
+
+// AccessLogWriter 异步访问日志写入器
+// 记录先进入有界队列，由后台 goroutine 按批量大小或时间间隔批量插入
+type AccessLogWriter struct {
+	db        *gorm.DB
+	broker    *AccessLogBroker
+	queue     chan *model.AdhocAccessLog
+	policy    string
+	batchSize int
+	interval  time.Duration
+
+	// mu 保护 closed 与 queue 的关闭：Write 持读锁发送，Close 持写锁关闭队列
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+
+	dropped    atomic.Int64
+	unreported atomic.Int64 // 上次输出日志以来丢弃的记录数
+	flushed    atomic.Int64
+}
+
+// AccessLogStats 写入器累计统计
+type AccessLogStats struct {
+	Queued  int   // 当前队列长度
+	Dropped int64 // 因队列满或写库失败丢弃的记录数
+	Flushed int64 // 成功写入的记录数
+}
+
+// NewAccessLogWriter 创建并启动写入器，写库成功的记录发布到 broker
+// 返回的 cleanup 会刷出队列中剩余的记录
+func NewAccessLogWriter(db *gorm.DB, broker *AccessLogBroker, conf config.AccessLogConfig) (AccessLogWriterInterface, func(), error) {
+	w, err := newAccessLogWriter(db, broker, conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	go w.run()
+
+	return w, w.Close, nil
+}
+
+// newAccessLogWriter 创建写入器但不启动后台刷写
+func newAccessLogWriter(db *gorm.DB, broker *AccessLogBroker, conf config.AccessLogConfig) (*AccessLogWriter, error) {
+	w := &AccessLogWriter{
+		db:        db,
+		broker:    broker,
+		policy:    conf.OverflowPolicy,
+		batchSize: conf.BatchSize,
+		interval:  conf.FlushInterval,
+		done:      make(chan struct{}),
+	}
+	switch w.policy {
+	case "":
+		w.policy = OverflowBlock
+	case OverflowBlock, OverflowDropOldest, OverflowError:
+	default:
+		return nil, fmt.Errorf("access log: unknown overflow policy %q", conf.OverflowPolicy)
+	}
+	if w.batchSize <= 0 {
+		w.batchSize = defaultAccessLogBatchSize
+	}
+	if w.interval <= 0 {
+		w.interval = defaultAccessLogFlushInterval
+	}
+	queueSize := conf.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultAccessLogQueueSize
+	}
+	w.queue = make(chan *model.AdhocAccessLog, queueSize)
+	return w, nil
+}
+
+// Write 把记录放入队列，队列满时按溢出策略处理
+func (w *AccessLogWriter) Write(ctx context.Context, record *model.AdhocAccessLog) error {
+	// 以进入队列的时间作为访问时间，而不是批量写库的时间
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return ErrAccessLogWriterClosed
+	}
+
+	select {
+	case w.queue <- record:
+		return nil
+	default:
+	}
+
+	switch w.policy {
+	case OverflowDropOldest:
+		for {
+			select {
+			case w.queue <- record:
+				return nil
+			default:
+			}
+			select {
+			case <-w.queue:
+				w.drop(OverflowDropOldest, 1)
+			default:
+			}
+		}
+	case OverflowError:
+		w.drop(OverflowError, 1)
+		return ErrAccessLogQueueFull
+	default:
+		select {
+		case w.queue <- record:
+			return nil
+		case <-ctx.Done():
+			w.drop(OverflowBlock, 1)
+			return ctx.Err()
+		}
+	}
+}
+
+// run 后台刷写：攒满一批或到达刷写间隔时写库，队列关闭后刷出剩余记录
+func (w *AccessLogWriter) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	batch := make([]*model.AdhocAccessLog, 0, w.batchSize)
+	for {
+		select {
+		case record, ok := <-w.queue:
+			if !ok {
+				w.flush(batch)
+				w.reportDropped()
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) >= w.batchSize {
+				w.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			w.reportDropped()
+			if len(batch) > 0 {
+				w.flush(batch)
+				batch = batch[:0]
+			}
+		}
+	}
+}
+
+// flush 批量插入一批记录，失败时整批计为丢弃
+func (w *AccessLogWriter) flush(batch []*model.AdhocAccessLog) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), accessLogFlushTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := w.db.WithContext(ctx).Create(&batch).Error
+	observability.RecordAccessLogFlush(len(batch), err, time.Since(start))
+	if err != nil {
+		log.GetLogger().Error("Failed to flush access logs", zap.Int("count", len(batch)), zap.Error(err))
+		w.drop("flush_failed", len(batch))
+		return
+	}
+	w.flushed.Add(int64(len(batch)))
+	w.broker.Publish(batch...)
+}
+
+func (w *AccessLogWriter) drop(reason string, n int) {
+	w.dropped.Add(int64(n))
+	w.unreported.Add(int64(n))
+	observability.RecordAccessLogDropped(reason, n)
+}
+
+// reportDropped 每个刷写间隔最多输出一条丢弃日志，汇总上次输出以来的丢弃数，避免过载时逐条告警刷屏
+// 按原因的明细见 access_log_dropped_total 指标，返回本次汇总的数量
+func (w *AccessLogWriter) reportDropped() int64 {
+	n := w.unreported.Swap(0)
+	if n > 0 {
+		log.GetLogger().Warn("Access logs dropped since last report",
+			zap.Int64("count", n), zap.String("overflow_policy", w.policy), zap.Int64("total", w.dropped.Load()))
+	}
+	return n
+}
+
+// Stats 返回累计统计
+func (w *AccessLogWriter) Stats() AccessLogStats {
+	return AccessLogStats{
+		Queued:  len(w.queue),
+		Dropped: w.dropped.Load(),
+		Flushed: w.flushed.Load(),
+	}
+}
+
+// Close 停止接收新记录，等待队列中的记录全部写库后返回，可重复调用
+func (w *AccessLogWriter) Close() {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+
+	<-w.done
+}
@@ -0,0 +1,118 @@
+package dal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"youlingserv/internal/adhoc/dal/model"
+	"youlingserv/internal/shared/migrations"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/database"
+	"youlingserv/pkg/migrate"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := database.Open(&database.Config{Driver: database.DriverSQLite, Database: ":memory:"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close(db) })
+	migrateUp(t, db, database.DriverSQLite)
+	return db
+}
+
+// migrateUp 用与线上相同的 SQL 迁移建表，约束与索引和生产一致
+func migrateUp(t *testing.T, db *gorm.DB, driver string) {
+	t.Helper()
+	ms, err := migrations.Load(driver)
+	require.NoError(t, err)
+	m := migrate.New(db, ms)
+	// 回滚全部已执行的迁移，复用的测试库从空表开始
+	_, err = m.Down(context.Background(), 0)
+	require.NoError(t, err)
+	_, err = m.Up(context.Background(), 0)
+	require.NoError(t, err)
+}
+
+func countAccessLogs(t *testing.T, db *gorm.DB) int64 {
+	var n int64
+	require.NoError(t, db.Model(&model.AdhocAccessLog{}).Count(&n).Error)
+	return n
+}
+
+func TestAccessLogWriter_FlushOnBatchAndClose(t *testing.T) {
+	db := newTestDB(t)
+	w, cleanup, err := NewAccessLogWriter(db, NewAccessLogBroker(config.AccessLogConfig{}), config.AccessLogConfig{BatchSize: 2, FlushInterval: time.Hour})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, w.Write(ctx, &model.AdhocAccessLog{Name: name, Action: "hello"}))
+	}
+	assert.Eventually(t, func() bool { return w.Stats().Flushed == 2 }, time.Second, 10*time.Millisecond)
+
+	// 不足一批的记录在关闭时刷出
+	cleanup()
+	assert.Equal(t, int64(3), countAccessLogs(t, db))
+	assert.ErrorIs(t, w.Write(ctx, &model.AdhocAccessLog{Name: "d"}), ErrAccessLogWriterClosed)
+}
+
+func TestAccessLogWriter_OverflowPolicies(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	// 未启动后台刷写，队列满后按策略处理
+	w, err := newAccessLogWriter(db, NewAccessLogBroker(config.AccessLogConfig{}), config.AccessLogConfig{QueueSize: 1, OverflowPolicy: OverflowError})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(ctx, &model.AdhocAccessLog{Name: "a"}))
+	assert.ErrorIs(t, w.Write(ctx, &model.AdhocAccessLog{Name: "b"}), ErrAccessLogQueueFull)
+	assert.Equal(t, int64(1), w.Stats().Dropped)
+
+	w, err = newAccessLogWriter(db, NewAccessLogBroker(config.AccessLogConfig{}), config.AccessLogConfig{QueueSize: 1, OverflowPolicy: OverflowDropOldest})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(ctx, &model.AdhocAccessLog{Name: "a"}))
+	require.NoError(t, w.Write(ctx, &model.AdhocAccessLog{Name: "b"}))
+	require.NoError(t, w.Write(ctx, &model.AdhocAccessLog{Name: "c"}))
+	assert.Equal(t, "c", (<-w.queue).Name)
+	assert.Equal(t, int64(2), w.Stats().Dropped)
+	// 丢弃日志按间隔汇总输出一次，之后重新计数
+	assert.Equal(t, int64(2), w.reportDropped())
+	assert.Equal(t, int64(0), w.reportDropped())
+
+	w, err = newAccessLogWriter(db, NewAccessLogBroker(config.AccessLogConfig{}), config.AccessLogConfig{QueueSize: 1, OverflowPolicy: OverflowBlock})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(ctx, &model.AdhocAccessLog{Name: "a"}))
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, w.Write(timeout, &model.AdhocAccessLog{Name: "b"}), context.DeadlineExceeded)
+
+	_, err = newAccessLogWriter(db, NewAccessLogBroker(config.AccessLogConfig{}), config.AccessLogConfig{OverflowPolicy: "spill"})
+	assert.Error(t, err)
+}
+
+func TestAdhocDAL_RecordAccess_ReturnsErrors(t *testing.T) {
+	db := newTestDB(t)
+	w, err := newAccessLogWriter(db, NewAccessLogBroker(config.AccessLogConfig{}), config.AccessLogConfig{QueueSize: 1, OverflowPolicy: OverflowError})
+	require.NoError(t, err)
+	d := NewAdhocDAL(db, w, NewAccessLogBroker(config.AccessLogConfig{}))
+	ctx := context.Background()
+
+	require.NoError(t, d.RecordAccess(ctx, "a", "hello"))
+	assert.ErrorIs(t, d.RecordAccess(ctx, "b", "hello"), ErrAccessLogQueueFull)
+
+	// 事务中同步写入，绕过队列
+	tm := database.NewTxManager(db, &database.Config{})
+	require.NoError(t, tm.WithinTx(ctx, func(ctx context.Context) error {
+		return d.RecordAccess(ctx, "c", "hello")
+	}))
+	assert.Equal(t, int64(1), countAccessLogs(t, db))
+
+	require.NoError(t, db.Migrator().DropTable(&model.AdhocAccessLog{}))
+	err = tm.WithinTx(ctx, func(ctx context.Context) error {
+		return d.RecordAccess(ctx, "d", "hello")
+	})
+	assert.Error(t, err)
+}
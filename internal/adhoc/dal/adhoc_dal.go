@@ -3,44 +3,188 @@ package dal
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"youlingserv/internal/adhoc/dal/model"
+	"youlingserv/pkg/database"
 	"youlingserv/pkg/log"
 )
 
 type AdhocDAL struct {
-	db *gorm.DB
+	db        *gorm.DB
+	logWriter AccessLogWriterInterface
+	broker    *AccessLogBroker
 }
 
-func NewAdhocDAL(db *gorm.DB) AdhocDALInterface {
+func NewAdhocDAL(db *gorm.DB, logWriter AccessLogWriterInterface, broker *AccessLogBroker) AdhocDALInterface {
 	return &AdhocDAL{
-		db: db,
+		db:        db,
+		logWriter: logWriter,
+		broker:    broker,
 	}
 }
 
+// RecordAccess 记录一次访问
+// 在事务中时同步写入，与事务一起提交或回滚；否则交给异步写入器批量写入
 func (d *AdhocDAL) RecordAccess(ctx context.Context, name, action string) error {
-	log.GetLogger().Info(fmt.Sprintf("RecordAccess: name=%s, action=%s", name, action))
+	log.FromContext(ctx).Info("RecordAccess", zap.String("name", name), zap.String("action", action))
 
 	record := &model.AdhocAccessLog{
 		Name:   name,
 		Action: action,
 	}
 
-	if err := d.db.WithContext(ctx).Create(record).Error; err != nil {
-		log.GetLogger().Error(fmt.Sprintf("Failed to record access: %v", err))
+	if database.InTx(ctx) {
+		if err := database.Conn(ctx, d.db).Create(record).Error; err != nil {
+			return fmt.Errorf("failed to record access: %w", err)
+		}
+		// 事务提交后才对订阅者可见
+		database.AfterCommit(ctx, func() { d.broker.Publish(record) })
 		return nil
 	}
 
+	if err := d.logWriter.Write(ctx, record); err != nil {
+		return fmt.Errorf("failed to record access: %w", err)
+	}
 	return nil
 }
 
-func (d *AdhocDAL) GetAccessLogs(ctx context.Context, name string) ([]*model.AdhocAccessLog, error) {
+// SubscribeAccessLogs 订阅此后落库的访问日志
+func (d *AdhocDAL) SubscribeAccessLogs() *AccessLogSubscription {
+	return d.broker.Subscribe()
+}
+
+// AccessLogFilter 访问日志查询条件，零值字段表示不过滤
+type AccessLogFilter struct {
+	Name   string
+	Action string
+	Start  time.Time // 含
+	End    time.Time // 不含
+}
+
+// AccessLogPage 游标分页参数，按 ID（写入顺序）排序
+type AccessLogPage struct {
+	AfterID int64 // 上一页最后一条的 ID，0 表示第一页
+	Desc    bool
+	Limit   int
+}
+
+// ActionCount 按动作统计的访问次数
+type ActionCount struct {
+	Action string
+	Count  int64
+}
+
+// DailyCount 按日期（YYYY-MM-DD）统计的访问次数
+type DailyCount struct {
+	Day   string
+	Count int64
+}
+
+// AccessStats 访问统计
+type AccessStats struct {
+	Total    int64
+	ByAction []ActionCount
+	ByDay    []DailyCount
+}
+
+// ListAccessLogs 按条件与游标查询访问日志
+func (d *AdhocDAL) ListAccessLogs(ctx context.Context, filter AccessLogFilter, page AccessLogPage) ([]*model.AdhocAccessLog, error) {
+	query := applyAccessLogFilter(database.Conn(ctx, d.db), filter)
+	if page.Desc {
+		if page.AfterID > 0 {
+			query = query.Where("id < ?", page.AfterID)
+		}
+		query = query.Order("id DESC")
+	} else {
+		if page.AfterID > 0 {
+			query = query.Where("id > ?", page.AfterID)
+		}
+		query = query.Order("id ASC")
+	}
+
 	var logs []*model.AdhocAccessLog
-	err := d.db.WithContext(ctx).Where("name = ?", name).Find(&logs).Error
-	if err != nil {
+	if err := query.Limit(page.Limit).Find(&logs).Error; err != nil {
 		return nil, err
 	}
 	return logs, nil
 }
+
+// GetAccessStats 统计符合条件的访问次数：总数、按动作、按日期
+func (d *AdhocDAL) GetAccessStats(ctx context.Context, filter AccessLogFilter) (*AccessStats, error) {
+	db := database.Conn(ctx, d.db)
+	stats := &AccessStats{}
+
+	err := applyAccessLogFilter(db.Model(&model.AdhocAccessLog{}), filter).
+		Select("action, COUNT(*) AS count").
+		Group("action").
+		Order("action").
+		Scan(&stats.ByAction).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range stats.ByAction {
+		stats.Total += c.Count
+	}
+
+	day := dayExpr(db.Dialector.Name())
+	err = applyAccessLogFilter(db.Model(&model.AdhocAccessLog{}), filter).
+		Select(day + " AS day, COUNT(*) AS count").
+		Group(day).
+		Order("day").
+		Scan(&stats.ByDay).Error
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func applyAccessLogFilter(query *gorm.DB, filter AccessLogFilter) *gorm.DB {
+	if filter.Name != "" {
+		query = query.Where("name = ?", filter.Name)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if !filter.Start.IsZero() {
+		query = query.Where("created_at >= ?", filter.Start)
+	}
+	if !filter.End.IsZero() {
+		query = query.Where("created_at < ?", filter.End)
+	}
+	return query
+}
+
+// dayExpr 把 created_at 格式化为 YYYY-MM-DD 的 SQL 表达式，各数据库的日期函数不同
+func dayExpr(dialect string) string {
+	switch dialect {
+	case database.DriverPostgres:
+		return "to_char(created_at, 'YYYY-MM-DD')"
+	case database.DriverSQLite:
+		return "strftime('%Y-%m-%d', created_at)"
+	default:
+		return "DATE_FORMAT(created_at, '%Y-%m-%d')"
+	}
+}
+
+// TouchUser 记录一次用户访问：不存在则创建，存在则累加访问次数
+func (d *AdhocDAL) TouchUser(ctx context.Context, name string) error {
+	now := time.Now()
+	user := &model.AdhocUser{
+		Name:       name,
+		VisitCount: 1,
+		LastSeenAt: &now,
+	}
+	return database.Conn(ctx, d.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "name"}},
+		DoUpdates: clause.Set{
+			// 必须带表名：PostgreSQL 的 DO UPDATE 中裸列名会与 EXCLUDED 的同名列冲突（ambiguous）
+			{Column: clause.Column{Name: "visit_count"}, Value: gorm.Expr("? + 1", clause.Column{Table: user.TableName(), Name: "visit_count"})},
+			{Column: clause.Column{Name: "last_seen_at"}, Value: now},
+		},
+	}).Create(user).Error
+}
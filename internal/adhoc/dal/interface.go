@@ -9,8 +9,21 @@ import (
 // AdhocDALInterface Adhoc 数据访问层接口
 type AdhocDALInterface interface {
 	RecordAccess(ctx context.Context, name, action string) error
-	GetAccessLogs(ctx context.Context, name string) ([]*model.AdhocAccessLog, error)
+	ListAccessLogs(ctx context.Context, filter AccessLogFilter, page AccessLogPage) ([]*model.AdhocAccessLog, error)
+	GetAccessStats(ctx context.Context, filter AccessLogFilter) (*AccessStats, error)
+	TouchUser(ctx context.Context, name string) error
+	SubscribeAccessLogs() *AccessLogSubscription
 }
 
 // Ensure AdhocDAL implements AdhocDALInterface
 var _ AdhocDALInterface = (*AdhocDAL)(nil)
+
+// AccessLogWriterInterface 访问日志写入器接口
+type AccessLogWriterInterface interface {
+	Write(ctx context.Context, record *model.AdhocAccessLog) error
+	Stats() AccessLogStats
+	Close()
+}
+
+// Ensure AccessLogWriter implements AccessLogWriterInterface
+var _ AccessLogWriterInterface = (*AccessLogWriter)(nil)
@@ -3,19 +3,22 @@ package model
 import "time"
 
 type AdhocUser struct {
-	ID   int64  `gorm:"primaryKey;autoIncrement" json:"id"`
-	Name string `gorm:"type:varchar(50);not null" json:"name"`
+	ID         int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name       string     `gorm:"type:varchar(50);not null;uniqueIndex" json:"name"`
+	VisitCount int64      `gorm:"not null;default:0" json:"visit_count"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
 }
 
 func (AdhocUser) TableName() string {
 	return "adhoc_users"
 }
 
+// AdhocAccessLog 访问日志，索引支持按名称 / 动作 / 时间范围查询与按日统计
 type AdhocAccessLog struct {
 	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	Name      string    `gorm:"type:varchar(50);not null" json:"name"`
-	Action    string    `gorm:"type:varchar(20);not null" json:"action"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	Name      string    `gorm:"type:varchar(50);not null;index:idx_adhoc_access_logs_name_created_at,priority:1" json:"name"`
+	Action    string    `gorm:"type:varchar(20);not null;index:idx_adhoc_access_logs_action_created_at,priority:1" json:"action"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index:idx_adhoc_access_logs_name_created_at,priority:2;index:idx_adhoc_access_logs_action_created_at,priority:2;index:idx_adhoc_access_logs_created_at" json:"created_at"`
 }
 
 func (AdhocAccessLog) TableName() string {
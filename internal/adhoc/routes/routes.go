@@ -7,7 +7,8 @@ import (
 	"youlingserv/internal/adhoc/service"
 )
 
-// RegisterAdhocRoutes 注册 Adhoc gRPC 服务
-func RegisterAdhocRoutes(grpcServer *grpc.Server, adhocService service.AdhocServiceInterface) {
+// RegisterAdhocRoutes 注册 Adhoc gRPC 服务与运维接口
+func RegisterAdhocRoutes(grpcServer *grpc.Server, adhocService service.AdhocServiceInterface, adminService service.AdminServiceInterface) {
 	adhocv1.RegisterAdhocServiceServer(grpcServer, adhocService.(*service.AdhocServiceImpl))
+	adhocv1.RegisterAdminServiceServer(grpcServer, adminService.(*service.AdminServiceImpl))
 }
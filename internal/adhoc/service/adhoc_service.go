@@ -2,10 +2,17 @@ package service
 
 import (
 	"context"
-	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	adhocv1 "youlingserv/gen/go/adhoc/v1"
 	"youlingserv/internal/adhoc/biz"
+	"youlingserv/internal/adhoc/dal"
+	"youlingserv/internal/adhoc/dal/model"
+	"youlingserv/internal/shared/errcode"
 	"youlingserv/pkg/log"
 )
 
@@ -21,16 +28,66 @@ func NewAdhocServiceImpl(adhocBiz biz.AdhocBizInterface) AdhocServiceInterface {
 }
 
 func (s *AdhocServiceImpl) Hello(ctx context.Context, req *adhocv1.HelloRequest) (*adhocv1.HelloResponse, error) {
-	log.GetLogger().Info(fmt.Sprintf("Adhoc Hello called: name=%s", req.Name))
-	return &adhocv1.HelloResponse{
-		Response: fmt.Sprintf("hello, %s!", req.Name),
-	}, nil
+	log.FromContext(ctx).Info("Adhoc Hello called", zap.String("name", req.GetName()))
+
+	msg, err := s.adhocBiz.ProcessHello(ctx, req.GetName())
+	if err != nil {
+		return nil, toStatus(ctx, "Hello", err)
+	}
+	return toHelloResponse(msg), nil
 }
 
 func (s *AdhocServiceImpl) Goodbye(ctx context.Context, req *adhocv1.GoodbyeRequest) (*adhocv1.GoodbyeResponse, error) {
-	log.GetLogger().Info(fmt.Sprintf("Adhoc Goodbye called: name=%s", req.Name))
+	log.FromContext(ctx).Info("Adhoc Goodbye called", zap.String("name", req.GetName()))
+
+	msg, err := s.adhocBiz.ProcessGoodbye(ctx, req.GetName())
+	if err != nil {
+		return nil, toStatus(ctx, "Goodbye", err)
+	}
+	return toGoodbyeResponse(msg), nil
+}
+
+func (s *AdhocServiceImpl) ListAccessLogs(ctx context.Context, req *adhocv1.ListAccessLogsRequest) (*adhocv1.ListAccessLogsResponse, error) {
+	logs, next, err := s.adhocBiz.ListAccessLogs(ctx, toAccessLogQuery(req))
+	if err != nil {
+		return nil, toStatus(ctx, "ListAccessLogs", err)
+	}
+	return toListAccessLogsResponse(logs, next), nil
+}
 
-	return &adhocv1.GoodbyeResponse{
-		Farewell: fmt.Sprintf("goodbye, %s~", req.Name),
-	}, nil
+func (s *AdhocServiceImpl) WatchAccessLogs(req *adhocv1.WatchRequest, stream grpc.ServerStreamingServer[adhocv1.AccessLogEvent]) error {
+	log.FromContext(stream.Context()).Info("Adhoc WatchAccessLogs started",
+		zap.String("name", req.GetName()), zap.String("action", req.GetAction()), zap.Int64("after_id", req.GetAfterId()))
+
+	filter := dal.AccessLogFilter{Name: req.GetName(), Action: req.GetAction()}
+	err := s.adhocBiz.WatchAccessLogs(stream.Context(), filter, req.GetAfterId(), func(l *model.AdhocAccessLog) error {
+		return stream.Send(toAccessLogEvent(l))
+	})
+	if err != nil {
+		return toStatus(stream.Context(), "WatchAccessLogs", err)
+	}
+	return nil
+}
+
+func (s *AdhocServiceImpl) GetAccessStats(ctx context.Context, req *adhocv1.GetAccessStatsRequest) (*adhocv1.GetAccessStatsResponse, error) {
+	stats, err := s.adhocBiz.GetAccessStats(ctx, dal.AccessLogFilter{
+		Name:  req.GetName(),
+		Start: toTime(req.GetStartTime()),
+		End:   toTime(req.GetEndTime()),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, "GetAccessStats", err)
+	}
+	return toGetAccessStatsResponse(stats), nil
+}
+
+// toStatus 记录 biz 错误的完整原因，返回给调用方的 status 只包含业务错误码和描述
+func toStatus(ctx context.Context, method string, err error) error {
+	st := errcode.ToStatus(err)
+	if status.Code(st) == codes.Internal || status.Code(st) == codes.Unknown {
+		log.FromContext(ctx).Error("Adhoc call failed", zap.String("method", method), zap.Error(err))
+	} else {
+		log.FromContext(ctx).Warn("Adhoc call rejected", zap.String("method", method), zap.Error(err))
+	}
+	return st
 }
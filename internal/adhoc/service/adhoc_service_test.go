@@ -0,0 +1,251 @@
+package service_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	adhocv1 "youlingserv/gen/go/adhoc/v1"
+	"youlingserv/gen/go/common"
+	"youlingserv/internal/adhoc/biz"
+	"youlingserv/internal/adhoc/dal"
+	"youlingserv/internal/adhoc/dal/model"
+	"youlingserv/internal/adhoc/routes"
+	"youlingserv/internal/adhoc/service"
+	"youlingserv/internal/shared/errcode"
+	"youlingserv/internal/shared/migrations"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/database"
+	"youlingserv/pkg/migrate"
+)
+
+// newTestStack 在内存 SQLite 上组装 service → biz → dal，并通过 bufconn 暴露 gRPC 服务
+func newTestStack(t *testing.T) (adhocv1.AdhocServiceClient, *gorm.DB, dal.AccessLogWriterInterface) {
+	db, err := database.Open(&database.Config{Driver: database.DriverSQLite, Database: ":memory:"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close(db) })
+
+	ms, err := migrations.Load(database.DriverSQLite)
+	require.NoError(t, err)
+	_, err = migrate.New(db, ms).Up(context.Background(), 0)
+	require.NoError(t, err)
+
+	broker := dal.NewAccessLogBroker(config.AccessLogConfig{})
+	t.Cleanup(broker.Close)
+	writer, flush, err := dal.NewAccessLogWriter(db, broker, config.AccessLogConfig{FlushInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	t.Cleanup(flush)
+
+	adhocBiz := biz.NewAdhocBiz(dal.NewAdhocDAL(db, writer, broker), database.NewTxManager(db, &database.Config{}))
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	routes.RegisterAdhocRoutes(srv, service.NewAdhocServiceImpl(adhocBiz), service.NewAdminServiceImpl())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return adhocv1.NewAdhocServiceClient(conn), db, writer
+}
+
+func TestAdhocService_RecordsAccess(t *testing.T) {
+	client, db, writer := newTestStack(t)
+	ctx := context.Background()
+
+	helloResp, err := client.Hello(ctx, &adhocv1.HelloRequest{Name: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello from Adhoc service, alice!", helloResp.GetResponse())
+
+	goodbyeResp, err := client.Goodbye(ctx, &adhocv1.GoodbyeRequest{Name: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, "Goodbye, alice! See you next time!", goodbyeResp.GetFarewell())
+
+	// Hello 与 Goodbye 的访问日志都经异步写入器写入，关闭写入器后全部落库
+	writer.Close()
+	assert.Equal(t, int64(2), writer.Stats().Flushed)
+
+	var actions []string
+	require.NoError(t, db.Model(&model.AdhocAccessLog{}).Where("name = ?", "alice").Order("id").Pluck("action", &actions).Error)
+	assert.Equal(t, []string{"hello", "goodbye"}, actions)
+
+	var user model.AdhocUser
+	require.NoError(t, db.Where("name = ?", "alice").First(&user).Error)
+	assert.Equal(t, int64(1), user.VisitCount)
+}
+
+func TestAdhocService_InvalidArgument(t *testing.T) {
+	client, _, _ := newTestStack(t)
+
+	for _, name := range []string{"", strings.Repeat("x", 51)} {
+		_, err := client.Hello(context.Background(), &adhocv1.HelloRequest{Name: name})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, common.ErrorCode_INVALID_ARGUMENT, errcode.CodeOf(err))
+	}
+}
+
+func TestAdhocService_InternalErrorHidesCause(t *testing.T) {
+	client, db, _ := newTestStack(t)
+	require.NoError(t, db.Migrator().DropTable(&model.AdhocUser{}))
+
+	_, err := client.Hello(context.Background(), &adhocv1.HelloRequest{Name: "bob"})
+	st := status.Convert(err)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "failed to record access", st.Message())
+	assert.Equal(t, common.ErrorCode_INTERNAL_ERROR, errcode.CodeOf(err))
+}
+
+func seedAccessLogs(t *testing.T, db *gorm.DB) {
+	day1 := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	logs := []*model.AdhocAccessLog{
+		{Name: "alice", Action: "hello", CreatedAt: day1},
+		{Name: "bob", Action: "hello", CreatedAt: day1.Add(time.Hour)},
+		{Name: "alice", Action: "goodbye", CreatedAt: day1.Add(2 * time.Hour)},
+		{Name: "alice", Action: "hello", CreatedAt: day2},
+		{Name: "bob", Action: "goodbye", CreatedAt: day2.Add(time.Hour)},
+	}
+	require.NoError(t, db.Create(&logs).Error)
+}
+
+func TestAdhocService_ListAccessLogs(t *testing.T) {
+	client, db, _ := newTestStack(t)
+	seedAccessLogs(t, db)
+	ctx := context.Background()
+
+	// 默认最新在前，逐页翻完
+	var ids []int64
+	req := &adhocv1.ListAccessLogsRequest{PageSize: 2}
+	for {
+		resp, err := client.ListAccessLogs(ctx, req)
+		require.NoError(t, err)
+		for _, l := range resp.GetLogs() {
+			ids = append(ids, l.GetId())
+		}
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+	assert.Equal(t, []int64{5, 4, 3, 2, 1}, ids)
+
+	resp, err := client.ListAccessLogs(ctx, &adhocv1.ListAccessLogsRequest{
+		Name:      "alice",
+		Action:    "hello",
+		StartTime: timestamppb.New(time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)),
+		Order:     adhocv1.SortOrder_SORT_ORDER_ASC,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetLogs(), 1)
+	assert.Equal(t, int64(4), resp.GetLogs()[0].GetId())
+	assert.Empty(t, resp.GetNextPageToken())
+
+	// 游标与排序方向不匹配
+	first, err := client.ListAccessLogs(ctx, &adhocv1.ListAccessLogsRequest{PageSize: 1})
+	require.NoError(t, err)
+	_, err = client.ListAccessLogs(ctx, &adhocv1.ListAccessLogsRequest{
+		PageToken: first.GetNextPageToken(),
+		Order:     adhocv1.SortOrder_SORT_ORDER_ASC,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAdhocService_GetAccessStats(t *testing.T) {
+	client, db, _ := newTestStack(t)
+	seedAccessLogs(t, db)
+
+	resp, err := client.GetAccessStats(context.Background(), &adhocv1.GetAccessStatsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), resp.GetTotal())
+	assert.Equal(t, []*adhocv1.ActionCount{{Action: "goodbye", Count: 2}, {Action: "hello", Count: 3}}, resp.GetByAction())
+	assert.Equal(t, []*adhocv1.DailyCount{{Date: "2026-10-01", Count: 3}, {Date: "2026-10-02", Count: 2}}, resp.GetByDay())
+
+	resp, err = client.GetAccessStats(context.Background(), &adhocv1.GetAccessStatsRequest{Name: "bob"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.GetTotal())
+
+	_, err = client.GetAccessStats(context.Background(), &adhocv1.GetAccessStatsRequest{
+		StartTime: timestamppb.New(time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)),
+		EndTime:   timestamppb.New(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAdhocService_WatchAccessLogs(t *testing.T) {
+	client, db, _ := newTestStack(t)
+	seedAccessLogs(t, db)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 从 ID 3 之后续传 alice 的记录：先补发 4，再推送实时记录
+	stream, err := client.WatchAccessLogs(ctx, &adhocv1.WatchRequest{Name: "alice", AfterId: 3})
+	require.NoError(t, err)
+	event, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), event.GetLog().GetId())
+
+	// 收到补发记录说明订阅已建立，此后落库的记录都会实时推送
+	_, err = client.Goodbye(ctx, &adhocv1.GoodbyeRequest{Name: "bob"})
+	require.NoError(t, err)
+	_, err = client.Hello(ctx, &adhocv1.HelloRequest{Name: "alice"})
+	require.NoError(t, err)
+	_, err = client.Goodbye(ctx, &adhocv1.GoodbyeRequest{Name: "alice"})
+	require.NoError(t, err)
+
+	var actions []string
+	for len(actions) < 2 {
+		event, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "alice", event.GetLog().GetName())
+		assert.Greater(t, event.GetLog().GetId(), int64(5))
+		actions = append(actions, event.GetLog().GetAction())
+	}
+	assert.ElementsMatch(t, []string{"hello", "goodbye"}, actions)
+
+	cancel()
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Canceled, status.Code(err))
+}
+
+func TestAdhocService_WatchAccessLogs_BackfillLimit(t *testing.T) {
+	client, db, _ := newTestStack(t)
+	logs := make([]*model.AdhocAccessLog, 10002)
+	for i := range logs {
+		logs[i] = &model.AdhocAccessLog{Name: "alice", Action: "hello"}
+	}
+	require.NoError(t, db.CreateInBatches(&logs, 500).Error)
+
+	// ID 1 之后有 10000 条以上的积压：补发满 10000 条后返回可续传的错误
+	stream, err := client.WatchAccessLogs(context.Background(), &adhocv1.WatchRequest{AfterId: 1})
+	require.NoError(t, err)
+	var lastID int64
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			assert.Contains(t, status.Convert(err).Message(), "after_id=10001")
+			break
+		}
+		lastID = event.GetLog().GetId()
+	}
+	assert.Equal(t, int64(10001), lastID)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	adhocv1 "youlingserv/gen/go/adhoc/v1"
+	"youlingserv/gen/go/common"
+	"youlingserv/internal/shared/errcode"
+	"youlingserv/pkg/log"
+)
+
+// AdminServiceImpl 运行时查询与调整 adhoc-server 的日志级别，与网关的 /api/v1/admin/log-level 对应
+type AdminServiceImpl struct {
+	adhocv1.UnimplementedAdminServiceServer
+}
+
+func NewAdminServiceImpl() AdminServiceInterface {
+	return &AdminServiceImpl{}
+}
+
+func (s *AdminServiceImpl) GetLogLevel(ctx context.Context, req *adhocv1.GetLogLevelRequest) (*adhocv1.LogLevelResponse, error) {
+	return &adhocv1.LogLevelResponse{Level: log.Level()}, nil
+}
+
+func (s *AdminServiceImpl) SetLogLevel(ctx context.Context, req *adhocv1.SetLogLevelRequest) (*adhocv1.LogLevelResponse, error) {
+	previous := log.Level()
+	if err := log.SetLevel(req.GetLevel()); err != nil {
+		return nil, errcode.New(common.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("invalid level %q", req.GetLevel())).GRPCStatus().Err()
+	}
+	log.FromContext(ctx).Warn("Log level changed", zap.String("from", previous), zap.String("to", log.Level()))
+	return &adhocv1.LogLevelResponse{Level: log.Level()}, nil
+}
@@ -0,0 +1,47 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	adhocv1 "youlingserv/gen/go/adhoc/v1"
+	"youlingserv/internal/adhoc/service"
+	"youlingserv/internal/shared/auth"
+	"youlingserv/pkg/log"
+)
+
+func TestAdminService_LogLevel(t *testing.T) {
+	original := log.Level()
+	t.Cleanup(func() { _ = log.SetLevel(original) })
+	svc := service.NewAdminServiceImpl()
+	ctx := context.Background()
+
+	resp, err := svc.SetLogLevel(ctx, &adhocv1.SetLogLevelRequest{Level: "warn"})
+	require.NoError(t, err)
+	assert.Equal(t, "warn", resp.GetLevel())
+
+	resp, err = svc.GetLogLevel(ctx, &adhocv1.GetLogLevelRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "warn", resp.GetLevel())
+
+	_, err = svc.SetLogLevel(ctx, &adhocv1.SetLogLevelRequest{Level: "verbose"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "warn", log.Level())
+}
+
+// 与网关的 /api/v1/admin/log-level 共用 api/log-level 授权规则
+func TestAdminService_SharesGatewayRule(t *testing.T) {
+	for method, action := range map[string]string{
+		adhocv1.AdminService_GetLogLevel_FullMethodName: "get",
+		adhocv1.AdminService_SetLogLevel_FullMethodName: "set",
+	} {
+		rule, ok := auth.MethodRule(method)
+		require.True(t, ok, method)
+		assert.Equal(t, auth.Rule{Resource: "api/log-level", Action: action}, rule)
+	}
+}
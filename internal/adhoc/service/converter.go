@@ -1,3 +1,89 @@
 package service
 
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	adhocv1 "youlingserv/gen/go/adhoc/v1"
+	"youlingserv/internal/adhoc/biz"
+	"youlingserv/internal/adhoc/dal"
+	"youlingserv/internal/adhoc/dal/model"
+)
+
 // Converter functions
+
+func toHelloResponse(msg string) *adhocv1.HelloResponse {
+	return &adhocv1.HelloResponse{
+		Response: msg,
+	}
+}
+
+func toGoodbyeResponse(msg string) *adhocv1.GoodbyeResponse {
+	return &adhocv1.GoodbyeResponse{
+		Farewell: msg,
+	}
+}
+
+func toAccessLogQuery(req *adhocv1.ListAccessLogsRequest) biz.AccessLogQuery {
+	return biz.AccessLogQuery{
+		Filter: dal.AccessLogFilter{
+			Name:   req.GetName(),
+			Action: req.GetAction(),
+			Start:  toTime(req.GetStartTime()),
+			End:    toTime(req.GetEndTime()),
+		},
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+		Desc:      req.GetOrder() != adhocv1.SortOrder_SORT_ORDER_ASC,
+	}
+}
+
+func toListAccessLogsResponse(logs []*model.AdhocAccessLog, next string) *adhocv1.ListAccessLogsResponse {
+	resp := &adhocv1.ListAccessLogsResponse{
+		Logs:          make([]*adhocv1.AccessLog, 0, len(logs)),
+		NextPageToken: next,
+	}
+	for _, l := range logs {
+		resp.Logs = append(resp.Logs, toAccessLog(l))
+	}
+	return resp
+}
+
+func toAccessLog(l *model.AdhocAccessLog) *adhocv1.AccessLog {
+	return &adhocv1.AccessLog{
+		Id:        l.ID,
+		Name:      l.Name,
+		Action:    l.Action,
+		CreatedAt: timestamppb.New(l.CreatedAt),
+	}
+}
+
+func toAccessLogEvent(l *model.AdhocAccessLog) *adhocv1.AccessLogEvent {
+	return &adhocv1.AccessLogEvent{
+		Log: toAccessLog(l),
+	}
+}
+
+func toGetAccessStatsResponse(stats *dal.AccessStats) *adhocv1.GetAccessStatsResponse {
+	resp := &adhocv1.GetAccessStatsResponse{
+		Total:    stats.Total,
+		ByAction: make([]*adhocv1.ActionCount, 0, len(stats.ByAction)),
+		ByDay:    make([]*adhocv1.DailyCount, 0, len(stats.ByDay)),
+	}
+	for _, c := range stats.ByAction {
+		resp.ByAction = append(resp.ByAction, &adhocv1.ActionCount{Action: c.Action, Count: c.Count})
+	}
+	for _, c := range stats.ByDay {
+		resp.ByDay = append(resp.ByDay, &adhocv1.DailyCount{Date: c.Day, Count: c.Count})
+	}
+	return resp
+}
+
+// toTime 未设置的 Timestamp 转为零值时间，表示不过滤
+func toTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
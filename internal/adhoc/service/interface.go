@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 
+	"google.golang.org/grpc"
+
 	adhocv1 "youlingserv/gen/go/adhoc/v1"
 )
 
@@ -10,7 +12,19 @@ import (
 type AdhocServiceInterface interface {
 	Hello(ctx context.Context, req *adhocv1.HelloRequest) (*adhocv1.HelloResponse, error)
 	Goodbye(ctx context.Context, req *adhocv1.GoodbyeRequest) (*adhocv1.GoodbyeResponse, error)
+	ListAccessLogs(ctx context.Context, req *adhocv1.ListAccessLogsRequest) (*adhocv1.ListAccessLogsResponse, error)
+	GetAccessStats(ctx context.Context, req *adhocv1.GetAccessStatsRequest) (*adhocv1.GetAccessStatsResponse, error)
+	WatchAccessLogs(req *adhocv1.WatchRequest, stream grpc.ServerStreamingServer[adhocv1.AccessLogEvent]) error
 }
 
 // Ensure AdhocServiceImpl implements AdhocServiceInterface
 var _ AdhocServiceInterface = (*AdhocServiceImpl)(nil)
+
+// AdminServiceInterface adhoc-server 运维接口
+type AdminServiceInterface interface {
+	GetLogLevel(ctx context.Context, req *adhocv1.GetLogLevelRequest) (*adhocv1.LogLevelResponse, error)
+	SetLogLevel(ctx context.Context, req *adhocv1.SetLogLevelRequest) (*adhocv1.LogLevelResponse, error)
+}
+
+// Ensure AdminServiceImpl implements AdminServiceInterface
+var _ AdminServiceInterface = (*AdminServiceImpl)(nil)
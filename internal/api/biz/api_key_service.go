@@ -0,0 +1,137 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"youlingserv/gen/go/common"
+	"youlingserv/internal/api/dal"
+	"youlingserv/internal/shared/auth"
+	"youlingserv/internal/shared/errcode"
+	"youlingserv/internal/shared/model"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/log"
+)
+
+const (
+	maxAPIKeyNameLength   = 100
+	maxAPIKeyOwnerLength  = 100
+	maxAPIKeyScopesLength = 1000
+)
+
+// CreateAPIKeyInput 创建 API key 的参数
+type CreateAPIKeyInput struct {
+	Name   string
+	Owner  string        // key 以该用户身份调用
+	Scopes []string      // resource:action，支持 * 通配
+	TTL    time.Duration // 有效期，0 使用 auth.api_keys.default_ttl
+}
+
+type APIKeyService struct {
+	apiKeyDAL  dal.APIKeyDALInterface
+	defaultTTL time.Duration
+	maxTTL     time.Duration
+}
+
+func NewAPIKeyService(apiKeyDAL dal.APIKeyDALInterface, conf config.AuthConfig) APIKeyServiceInterface {
+	return &APIKeyService{
+		apiKeyDAL:  apiKeyDAL,
+		defaultTTL: conf.APIKeys.DefaultTTL,
+		maxTTL:     conf.APIKeys.MaxTTL,
+	}
+}
+
+func (s *APIKeyService) Create(ctx context.Context, in *CreateAPIKeyInput) (*model.APIKey, string, error) {
+	key, err := s.newAPIKey(in)
+	if err != nil {
+		return nil, "", err
+	}
+	plaintext, err := s.apiKeyDAL.Create(ctx, key)
+	if err != nil {
+		return nil, "", errcode.Wrap(common.ErrorCode_INTERNAL_ERROR, err, "failed to create api key")
+	}
+	log.FromContext(ctx).Info("API key created",
+		zap.String("key_id", key.KeyID), zap.String("name", key.Name), zap.String("owner", key.Owner), zap.String("scopes", key.Scopes))
+	return key, plaintext, nil
+}
+
+func (s *APIKeyService) List(ctx context.Context, owner string) ([]*model.APIKey, error) {
+	return s.apiKeyDAL.List(ctx, owner)
+}
+
+func (s *APIKeyService) Rotate(ctx context.Context, keyID string) (*model.APIKey, string, error) {
+	key, plaintext, err := s.apiKeyDAL.Rotate(ctx, keyID)
+	if errors.Is(err, auth.ErrAPIKeyRevoked) {
+		return nil, "", errcode.New(common.ErrorCode_INVALID_ARGUMENT, "api key is revoked and cannot be rotated")
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	log.FromContext(ctx).Info("API key rotated", zap.String("key_id", keyID))
+	return key, plaintext, nil
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, keyID string) (*model.APIKey, error) {
+	key, err := s.apiKeyDAL.Revoke(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	log.FromContext(ctx).Info("API key revoked", zap.String("key_id", keyID))
+	return key, nil
+}
+
+// newAPIKey 校验参数并生成待保存的记录，scopes 去重后按原顺序保存
+func (s *APIKeyService) newAPIKey(in *CreateAPIKeyInput) (*model.APIKey, error) {
+	name, owner := strings.TrimSpace(in.Name), strings.TrimSpace(in.Owner)
+	switch {
+	case name == "":
+		return nil, errcode.New(common.ErrorCode_INVALID_ARGUMENT, "name is required")
+	case len(name) > maxAPIKeyNameLength:
+		return nil, errcode.New(common.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("name must be at most %d characters", maxAPIKeyNameLength))
+	case owner == "":
+		return nil, errcode.New(common.ErrorCode_INVALID_ARGUMENT, "owner is required")
+	case len(owner) > maxAPIKeyOwnerLength:
+		return nil, errcode.New(common.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("owner must be at most %d characters", maxAPIKeyOwnerLength))
+	case len(in.Scopes) == 0:
+		return nil, errcode.New(common.ErrorCode_INVALID_ARGUMENT, "at least one scope is required")
+	}
+
+	seen := make(map[string]bool, len(in.Scopes))
+	var scopes []string
+	for _, scope := range in.Scopes {
+		if err := auth.ValidateScope(scope); err != nil {
+			return nil, errcode.New(common.ErrorCode_INVALID_ARGUMENT, err.Error())
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	joined := strings.Join(scopes, " ")
+	if len(joined) > maxAPIKeyScopesLength {
+		return nil, errcode.New(common.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("scopes must be at most %d characters in total", maxAPIKeyScopesLength))
+	}
+
+	ttl := in.TTL
+	switch {
+	case ttl < 0:
+		return nil, errcode.New(common.ErrorCode_INVALID_ARGUMENT, "ttl must not be negative")
+	case ttl == 0:
+		ttl = s.defaultTTL
+	}
+	if s.maxTTL > 0 && (ttl == 0 || ttl > s.maxTTL) {
+		return nil, errcode.New(common.ErrorCode_INVALID_ARGUMENT, fmt.Sprintf("ttl must be at most %s", s.maxTTL))
+	}
+
+	key := &model.APIKey{Name: name, Owner: owner, Scopes: joined}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		key.ExpiresAt = &expiresAt
+	}
+	return key, nil
+}
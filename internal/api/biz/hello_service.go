@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"go.uber.org/zap"
+
 	"youlingserv/internal/api/dal"
 	"youlingserv/pkg/log"
 )
@@ -19,7 +21,7 @@ func NewHelloService(userDAL dal.UserDALInterface) HelloServiceInterface {
 }
 
 func (s *HelloService) SayHello(ctx context.Context, name, userID string) (string, error) {
-	log.GetLogger().Info(fmt.Sprintf("SayHello called: name=%s, userID=%s", name, userID))
+	log.FromContext(ctx).Info("SayHello called", zap.String("name", name))
 
 	user, err := s.userDAL.GetUserByUsername(ctx, name)
 	if err != nil {
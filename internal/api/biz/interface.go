@@ -1,11 +1,26 @@
 package biz
 
-import "context"
+import (
+	"context"
+
+	"youlingserv/internal/shared/model"
+)
 
 // HelloServiceInterface Hello 业务逻辑接口
 type HelloServiceInterface interface {
 	SayHello(ctx context.Context, name, userID string) (string, error)
 }
 
+// APIKeyServiceInterface API key 管理接口，返回的明文 key 只在创建和轮换时出现一次
+type APIKeyServiceInterface interface {
+	Create(ctx context.Context, in *CreateAPIKeyInput) (*model.APIKey, string, error)
+	List(ctx context.Context, owner string) ([]*model.APIKey, error)
+	Rotate(ctx context.Context, keyID string) (*model.APIKey, string, error)
+	Revoke(ctx context.Context, keyID string) (*model.APIKey, error)
+}
+
 // Ensure HelloService implements HelloServiceInterface
 var _ HelloServiceInterface = (*HelloService)(nil)
+
+// Ensure APIKeyService implements APIKeyServiceInterface
+var _ APIKeyServiceInterface = (*APIKeyService)(nil)
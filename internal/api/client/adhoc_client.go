@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	"youlingserv/internal/shared/auth"
+	grpcMiddleware "youlingserv/internal/shared/middleware/grpc"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/grpcclient"
+	"youlingserv/pkg/log"
+	"youlingserv/pkg/tlsconfig"
+)
+
+// AdhocConn 指向 adhoc-server 的 gRPC 连接
+// 单独定义类型以便 Wire 区分不同下游服务的连接
+type AdhocConn grpc.ClientConnInterface
+
+// NewAdhocConn 创建访问 adhoc-server 的连接池，启用 tls 时使用 TLS / mTLS
+// 返回的 cleanup 用于在进程退出时关闭连接池
+func NewAdhocConn(cfg config.AdhocClientConfig) (AdhocConn, func(), error) {
+	tlsConf, closeTLS, err := tlsconfig.NewClientConfig(cfg.TLS)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load adhoc client TLS config: %w", err)
+	}
+	opts := []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(
+			grpcMiddleware.TracingClientInterceptor(),
+			grpcMiddleware.RequestIDClientInterceptor(),
+			timeoutInterceptor(cfg.Timeout),
+		),
+	}
+	if tlsConf != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConf)))
+	}
+
+	pool, err := grpcclient.NewPool(cfg.Target, cfg.PoolSize, opts...)
+	if err != nil {
+		closeTLS()
+		return nil, nil, fmt.Errorf("failed to create adhoc client: %w", err)
+	}
+
+	cleanup := func() {
+		if err := pool.Close(); err != nil {
+			log.GetLogger().Error("Failed to close adhoc client pool", zap.Error(err))
+		}
+		closeTLS()
+	}
+	return pool, cleanup, nil
+}
+
+// WithCredentials 把调用方凭证透传为 AuthInterceptor 读取的 authorization / user-id metadata
+// adhoc-server 按自身的认证模式重新校验，不信任网关的认证结果
+func WithCredentials(ctx context.Context, cred auth.Credentials) context.Context {
+	var kv []string
+	if cred.Authorization != "" {
+		kv = append(kv, "authorization", cred.Authorization)
+	}
+	if cred.UserID != "" {
+		kv = append(kv, "user-id", cred.UserID)
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// timeoutInterceptor 为未设置 deadline 的调用补充默认超时
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -3,6 +3,7 @@ package dal
 import (
 	"context"
 
+	"youlingserv/internal/shared/auth"
 	"youlingserv/internal/shared/model"
 )
 
@@ -15,3 +16,15 @@ type UserDALInterface interface {
 
 // Ensure UserDAL implements UserDALInterface
 var _ UserDALInterface = (*UserDAL)(nil)
+
+// APIKeyDALInterface API key 数据访问层接口，由 auth.APIKeyStore 实现，与认证共用同一份存储
+type APIKeyDALInterface interface {
+	Create(ctx context.Context, key *model.APIKey) (string, error)
+	Get(ctx context.Context, keyID string) (*model.APIKey, error)
+	List(ctx context.Context, owner string) ([]*model.APIKey, error)
+	Rotate(ctx context.Context, keyID string) (*model.APIKey, string, error)
+	Revoke(ctx context.Context, keyID string) (*model.APIKey, error)
+}
+
+// Ensure auth.APIKeyStore implements APIKeyDALInterface
+var _ APIKeyDALInterface = (*auth.APIKeyStore)(nil)
@@ -6,6 +6,7 @@ import (
 	"gorm.io/gorm"
 
 	"youlingserv/internal/shared/model"
+	"youlingserv/pkg/database"
 )
 
 type UserDAL struct {
@@ -20,7 +21,7 @@ func NewUserDAL(db *gorm.DB) UserDALInterface {
 
 func (d *UserDAL) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
 	var user model.User
-	err := d.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	err := database.Conn(ctx, d.db).Where("username = ?", username).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +30,7 @@ func (d *UserDAL) GetUserByUsername(ctx context.Context, username string) (*mode
 
 func (d *UserDAL) GetUserByID(ctx context.Context, id int64) (*model.User, error) {
 	var user model.User
-	err := d.db.WithContext(ctx).First(&user, id).Error
+	err := database.Conn(ctx, d.db).First(&user, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -37,5 +38,5 @@ func (d *UserDAL) GetUserByID(ctx context.Context, id int64) (*model.User, error
 }
 
 func (d *UserDAL) CreateUser(ctx context.Context, user *model.User) error {
-	return d.db.WithContext(ctx).Create(user).Error
+	return database.Conn(ctx, d.db).Create(user).Error
 }
@@ -0,0 +1,185 @@
+package gateway
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// pathParam 路由参数与请求字段路径（如 "user.name"）的对应关系
+type pathParam struct {
+	name      string
+	fieldPath string
+}
+
+// parseTemplate 把 google.api.http 路径模板转换为 Hertz 路由
+// 支持 {field}、{field=*} 和位于末尾的 {field=**}，不支持自定义动词
+func parseTemplate(tmpl string) (string, []pathParam, error) {
+	if !strings.HasPrefix(tmpl, "/") {
+		return "", nil, fmt.Errorf("path template %q must start with /", tmpl)
+	}
+
+	var (
+		sb     strings.Builder
+		params []pathParam
+	)
+	for i := 0; i < len(tmpl); i++ {
+		ch := tmpl[i]
+		switch ch {
+		case '{':
+			end := strings.IndexByte(tmpl[i:], '}')
+			if end < 0 {
+				return "", nil, fmt.Errorf("path template %q: unclosed variable", tmpl)
+			}
+			field, pattern, _ := strings.Cut(tmpl[i+1:i+end], "=")
+			name := fmt.Sprintf("p%d", len(params))
+			switch pattern {
+			case "", "*":
+				sb.WriteString(":" + name)
+			case "**":
+				if i+end+1 != len(tmpl) {
+					return "", nil, fmt.Errorf("path template %q: ** must be the last segment", tmpl)
+				}
+				sb.WriteString("*" + name)
+			default:
+				return "", nil, fmt.Errorf("path template %q: unsupported variable pattern %q", tmpl, pattern)
+			}
+			params = append(params, pathParam{name: name, fieldPath: field})
+			i += end
+		case ':':
+			return "", nil, fmt.Errorf("path template %q: custom verbs are not supported", tmpl)
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return sb.String(), params, nil
+}
+
+// topLevelField 返回字段路径的第一段
+func topLevelField(fieldPath string) string {
+	top, _, _ := strings.Cut(fieldPath, ".")
+	return top
+}
+
+// resolveField 按字段路径查找字段描述，路径中每段可以是 proto 名或 JSON 名
+func resolveField(md protoreflect.MessageDescriptor, fieldPath string) ([]protoreflect.FieldDescriptor, error) {
+	segments := strings.Split(fieldPath, ".")
+	fds := make([]protoreflect.FieldDescriptor, 0, len(segments))
+	for i, seg := range segments {
+		fd := md.Fields().ByName(protoreflect.Name(seg))
+		if fd == nil {
+			fd = md.Fields().ByJSONName(seg)
+		}
+		if fd == nil {
+			return nil, fmt.Errorf("field %q not found in %s", fieldPath, md.FullName())
+		}
+		if i < len(segments)-1 {
+			if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+				return nil, fmt.Errorf("field %q: %s is not a singular message", fieldPath, seg)
+			}
+			md = fd.Message()
+		}
+		fds = append(fds, fd)
+	}
+	return fds, nil
+}
+
+// setField 把字符串形式的值写入字段路径指向的字段
+func setField(msg protoreflect.Message, fieldPath string, values []string) error {
+	fds, err := resolveField(msg.Descriptor(), fieldPath)
+	if err != nil {
+		return err
+	}
+	for _, fd := range fds[:len(fds)-1] {
+		msg = msg.Mutable(fd).Message()
+	}
+
+	fd := fds[len(fds)-1]
+	switch {
+	case fd.IsMap():
+		return fmt.Errorf("field %q: map fields cannot be bound from the URL", fieldPath)
+	case fd.IsList():
+		list := msg.Mutable(fd).List()
+		for _, v := range values {
+			val, err := parseValue(msg, fd, v)
+			if err != nil {
+				return fmt.Errorf("field %q: %w", fieldPath, err)
+			}
+			list.Append(val)
+		}
+	default:
+		if len(values) != 1 {
+			return fmt.Errorf("field %q: expected a single value, got %d", fieldPath, len(values))
+		}
+		val, err := parseValue(msg, fd, values[0])
+		if err != nil {
+			return fmt.Errorf("field %q: %w", fieldPath, err)
+		}
+		msg.Set(fd, val)
+	}
+	return nil
+}
+
+// parseValue 按字段类型解析字符串值
+// 消息类型（如 Timestamp、Wrapper）按其 JSON 表示解析
+func parseValue(parent protoreflect.Message, fd protoreflect.FieldDescriptor, v string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(v)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(v, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(v, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(v, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(v, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(v, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(v), nil
+	case protoreflect.BytesKind:
+		b, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			b, err = base64.URLEncoding.DecodeString(v)
+		}
+		return protoreflect.ValueOfBytes(b), err
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(v)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid enum value %q", v)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		var sub protoreflect.Message
+		if fd.IsList() {
+			sub = parent.Mutable(fd).List().NewElement().Message()
+		} else {
+			sub = parent.NewField(fd).Message()
+		}
+		// 先按 JSON 字符串解析（Timestamp、StringValue 等），失败再按原始 JSON 解析（BoolValue、Int32Value 等）
+		if err := unmarshalOptions.Unmarshal([]byte(strconv.Quote(v)), sub.Interface()); err != nil {
+			if err := unmarshalOptions.Unmarshal([]byte(v), sub.Interface()); err != nil {
+				return protoreflect.Value{}, fmt.Errorf("invalid value %q for %s", v, fd.Message().FullName())
+			}
+		}
+		return protoreflect.ValueOfMessage(sub), nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", fd.Kind())
+	}
+}
@@ -0,0 +1,272 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/route"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	adhocv1 "youlingserv/gen/go/adhoc/v1"
+	"youlingserv/internal/api/client"
+	httpMiddleware "youlingserv/internal/shared/middleware/http"
+	"youlingserv/pkg/dto"
+	"youlingserv/pkg/log"
+)
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// Transcoder 读取 proto 中的 google.api.http 注解，自动生成 REST 路由
+// 并把 HTTP 请求转码为 gRPC 调用
+type Transcoder struct {
+	bindings []*binding
+}
+
+// binding 一条 HTTP 规则与 gRPC 方法的绑定关系
+type binding struct {
+	httpMethod   string
+	path         string      // Hertz 路由
+	pathParams   []pathParam // 路由参数 → 请求字段
+	body         string      // "" / "*" / 顶层字段名
+	responseBody string      // "" 表示整个响应
+	fullMethod   string      // /package.Service/Method
+	method       protoreflect.MethodDescriptor
+	conn         grpc.ClientConnInterface
+}
+
+// NewTranscoder 创建转码器并注册网关需要暴露的服务
+func NewTranscoder(adhocConn client.AdhocConn) (*Transcoder, error) {
+	t := &Transcoder{}
+	if err := t.RegisterService(adhocv1.AdhocService_ServiceDesc.ServiceName, adhocConn); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// RegisterService 读取服务中所有带 HTTP 注解的方法，调用转发到 conn
+// 流式方法不支持转码，直接跳过
+func (t *Transcoder) RegisterService(serviceName string, conn grpc.ClientConnInterface) error {
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return fmt.Errorf("gateway: service %s not found: %w", serviceName, err)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return fmt.Errorf("gateway: %s is not a service", serviceName)
+	}
+
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		if md.IsStreamingClient() || md.IsStreamingServer() {
+			continue
+		}
+		if !proto.HasExtension(md.Options(), annotations.E_Http) {
+			continue
+		}
+		rule := proto.GetExtension(md.Options(), annotations.E_Http).(*annotations.HttpRule)
+
+		rules := append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...)
+		for _, r := range rules {
+			b, err := newBinding(md, r, conn)
+			if err != nil {
+				return fmt.Errorf("gateway: %s: %w", md.FullName(), err)
+			}
+			t.bindings = append(t.bindings, b)
+		}
+	}
+	return nil
+}
+
+// Route 一条转码路由
+type Route struct {
+	HTTPMethod string
+	Path       string // Hertz 路由模式
+	FullMethod string // /package.Service/Method
+}
+
+// Routes 返回所有转码路由，供鉴权等按 RPC 声明规则的组件使用
+func (t *Transcoder) Routes() []Route {
+	routes := make([]Route, 0, len(t.bindings))
+	for _, b := range t.bindings {
+		routes = append(routes, Route{HTTPMethod: b.httpMethod, Path: b.path, FullMethod: b.fullMethod})
+	}
+	return routes
+}
+
+// Mount 把所有绑定挂载到 Hertz 路由上
+func (t *Transcoder) Mount(r route.IRoutes) {
+	for _, b := range t.bindings {
+		r.Handle(b.httpMethod, b.path, b.handle)
+		log.GetLogger().Info("Gateway route", zap.String("http_method", b.httpMethod), zap.String("path", b.path), zap.String(log.FieldRPCMethod, b.fullMethod))
+	}
+}
+
+func newBinding(md protoreflect.MethodDescriptor, rule *annotations.HttpRule, conn grpc.ClientConnInterface) (*binding, error) {
+	var httpMethod, tmpl string
+	switch p := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		httpMethod, tmpl = http.MethodGet, p.Get
+	case *annotations.HttpRule_Put:
+		httpMethod, tmpl = http.MethodPut, p.Put
+	case *annotations.HttpRule_Post:
+		httpMethod, tmpl = http.MethodPost, p.Post
+	case *annotations.HttpRule_Delete:
+		httpMethod, tmpl = http.MethodDelete, p.Delete
+	case *annotations.HttpRule_Patch:
+		httpMethod, tmpl = http.MethodPatch, p.Patch
+	case *annotations.HttpRule_Custom:
+		httpMethod, tmpl = p.Custom.GetKind(), p.Custom.GetPath()
+	default:
+		return nil, fmt.Errorf("http rule has no pattern")
+	}
+
+	path, params, err := parseTemplate(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range params {
+		if _, err := resolveField(md.Input(), p.fieldPath); err != nil {
+			return nil, fmt.Errorf("path %q: %w", tmpl, err)
+		}
+	}
+	if body := rule.GetBody(); body != "" && body != "*" {
+		if md.Input().Fields().ByName(protoreflect.Name(body)) == nil {
+			return nil, fmt.Errorf("body field %q not found in %s", body, md.Input().FullName())
+		}
+	}
+	if rb := rule.GetResponseBody(); rb != "" {
+		if md.Output().Fields().ByName(protoreflect.Name(rb)) == nil {
+			return nil, fmt.Errorf("response_body field %q not found in %s", rb, md.Output().FullName())
+		}
+	}
+
+	return &binding{
+		httpMethod:   httpMethod,
+		path:         path,
+		pathParams:   params,
+		body:         rule.GetBody(),
+		responseBody: rule.GetResponseBody(),
+		fullMethod:   fmt.Sprintf("/%s/%s", md.Parent().FullName(), md.Name()),
+		method:       md,
+		conn:         conn,
+	}, nil
+}
+
+// handle 绑定请求字段 → 发起 gRPC 调用 → 以 protojson 渲染响应
+func (b *binding) handle(ctx context.Context, c *app.RequestContext) {
+	req := newMessage(b.method.Input())
+	if err := b.bindRequest(c, req.ProtoReflect()); err != nil {
+		c.JSON(400, dto.ErrorResponse(ctx, 400, "invalid request: "+err.Error()))
+		return
+	}
+
+	resp := newMessage(b.method.Output())
+	ctx = client.WithCredentials(ctx, httpMiddleware.Credentials(c))
+	if err := b.conn.Invoke(ctx, b.fullMethod, req, resp); err != nil {
+		c.JSON(dto.GRPCErrorResponse(ctx, err))
+		return
+	}
+
+	data, err := b.renderResponse(resp)
+	if err != nil {
+		c.JSON(500, dto.ErrorResponse(ctx, 500, "failed to render response: "+err.Error()))
+		return
+	}
+	c.JSON(200, dto.SuccessResponse(data))
+}
+
+// bindRequest 按 body → path → query 的顺序填充请求消息
+func (b *binding) bindRequest(c *app.RequestContext, msg protoreflect.Message) error {
+	if err := b.bindBody(c.Request.Body(), msg); err != nil {
+		return err
+	}
+
+	bound := make(map[string]bool, len(b.pathParams))
+	for _, p := range b.pathParams {
+		if err := setField(msg, p.fieldPath, []string{c.Param(p.name)}); err != nil {
+			return err
+		}
+		bound[p.fieldPath] = true
+	}
+
+	// body 为 "*" 时所有字段都来自 body，不再读取 query
+	if b.body == "*" {
+		return nil
+	}
+
+	query := make(map[string][]string)
+	c.QueryArgs().VisitAll(func(key, value []byte) {
+		query[string(key)] = append(query[string(key)], string(value))
+	})
+	for key, values := range query {
+		if bound[key] || (b.body != "" && topLevelField(key) == b.body) {
+			continue
+		}
+		if _, err := resolveField(msg.Descriptor(), key); err != nil {
+			// 忽略未知 query 参数（如缓存破坏参数）
+			continue
+		}
+		if err := setField(msg, key, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *binding) bindBody(body []byte, msg protoreflect.Message) error {
+	if b.body == "" || len(body) == 0 {
+		return nil
+	}
+	if b.body == "*" {
+		return unmarshalOptions.Unmarshal(body, msg.Interface())
+	}
+
+	fd := msg.Descriptor().Fields().ByName(protoreflect.Name(b.body))
+	if fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap() {
+		return unmarshalOptions.Unmarshal(body, msg.Mutable(fd).Message().Interface())
+	}
+	// 非消息字段：包一层对象后整体反序列化
+	wrapped := make([]byte, 0, len(body)+len(b.body)+4)
+	wrapped = append(wrapped, `{"`+b.body+`":`...)
+	wrapped = append(wrapped, body...)
+	wrapped = append(wrapped, '}')
+	return unmarshalOptions.Unmarshal(wrapped, msg.Interface())
+}
+
+func (b *binding) renderResponse(resp proto.Message) (json.RawMessage, error) {
+	data, err := marshalOptions.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	if b.responseBody == "" {
+		return data, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields[b.responseBody], nil
+}
+
+// newMessage 优先使用已注册的生成类型，找不到时退化为 dynamicpb
+func newMessage(md protoreflect.MessageDescriptor) proto.Message {
+	if mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName()); err == nil {
+		return mt.New().Interface()
+	}
+	return dynamicpb.NewMessage(md)
+}
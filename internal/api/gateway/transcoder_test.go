@@ -0,0 +1,111 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	adhocv1 "youlingserv/gen/go/adhoc/v1"
+)
+
+// fakeAdhocServer 回显请求，并在 name 为 "denied" 时返回 PermissionDenied
+type fakeAdhocServer struct {
+	adhocv1.UnimplementedAdhocServiceServer
+}
+
+func (s *fakeAdhocServer) Hello(ctx context.Context, req *adhocv1.HelloRequest) (*adhocv1.HelloResponse, error) {
+	if req.Name == "denied" {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	return &adhocv1.HelloResponse{Response: "hello, " + req.Name + " from " + md.Get("user-id")[0]}, nil
+}
+
+func newTestEngine(t *testing.T) *route.Engine {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	adhocv1.RegisterAdhocServiceServer(srv, &fakeAdhocServer{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	transcoder, err := NewTranscoder(conn)
+	require.NoError(t, err)
+
+	engine := route.NewEngine(config.NewOptions(nil))
+	// 模拟客户端携带的凭证，网关原样透传给下游
+	engine.Use(func(ctx context.Context, c *app.RequestContext) {
+		c.Request.Header.Set("X-User-ID", "user123")
+		c.Next(ctx)
+	})
+	transcoder.Mount(engine)
+	return engine
+}
+
+func TestTranscoder_PostWithBody(t *testing.T) {
+	engine := newTestEngine(t)
+
+	w := ut.PerformRequest(engine, "POST", "/v1/hello",
+		&ut.Body{Body: bytes.NewBufferString(`{"name":"Alice"}`), Len: -1},
+		ut.Header{Key: "Content-Type", Value: "application/json"},
+	)
+
+	resp := w.Result()
+	assert.Equal(t, 200, resp.StatusCode())
+	assert.JSONEq(t, `{"code":0,"msg":"success","data":{"response":"hello, Alice from user123"}}`, string(resp.Body()))
+}
+
+func TestTranscoder_GetWithPathParam(t *testing.T) {
+	engine := newTestEngine(t)
+
+	w := ut.PerformRequest(engine, "GET", "/v1/hello/Bob", nil)
+
+	resp := w.Result()
+	assert.Equal(t, 200, resp.StatusCode())
+	assert.JSONEq(t, `{"code":0,"msg":"success","data":{"response":"hello, Bob from user123"}}`, string(resp.Body()))
+}
+
+func TestTranscoder_GRPCError(t *testing.T) {
+	engine := newTestEngine(t)
+
+	w := ut.PerformRequest(engine, "GET", "/v1/hello/denied", nil)
+
+	resp := w.Result()
+	assert.Equal(t, 403, resp.StatusCode())
+	assert.JSONEq(t, `{"code":403,"msg":"permission denied"}`, string(resp.Body()))
+}
+
+func TestParseTemplate(t *testing.T) {
+	path, params, err := parseTemplate("/v1/{parent=*}/books/{name=**}")
+	require.NoError(t, err)
+	assert.Equal(t, "/v1/:p0/books/*p1", path)
+	assert.Equal(t, []pathParam{{name: "p0", fieldPath: "parent"}, {name: "p1", fieldPath: "name"}}, params)
+
+	_, _, err = parseTemplate("/v1/{name=shelves/*}")
+	assert.Error(t, err)
+
+	_, _, err = parseTemplate("/v1/books:publish")
+	assert.Error(t, err)
+}
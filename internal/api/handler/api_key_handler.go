@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/utils"
+
+	"youlingserv/internal/api/biz"
+	"youlingserv/internal/shared/errcode"
+	"youlingserv/pkg/dto"
+)
+
+// APIKeyHandler API key 管理端点
+type APIKeyHandler struct {
+	apiKeyService biz.APIKeyServiceInterface
+}
+
+func NewAPIKeyHandler(apiKeyService biz.APIKeyServiceInterface) APIKeyHandlerInterface {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Owner  string   `json:"owner"`  // 缺省为调用方自己
+	Scopes []string `json:"scopes"` // resource:action
+	TTL    string   `json:"ttl"`    // Go duration，如 720h；缺省使用 auth.api_keys.default_ttl
+}
+
+func (h *APIKeyHandler) Create(ctx context.Context, c *app.RequestContext) {
+	var req CreateAPIKeyRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(400, dto.ErrorResponse(ctx, 400, "invalid request: "+err.Error()))
+		return
+	}
+	var ttl time.Duration
+	if req.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(req.TTL); err != nil {
+			c.JSON(400, dto.ErrorResponse(ctx, 400, "invalid ttl: "+err.Error()))
+			return
+		}
+	}
+	if req.Owner == "" {
+		userID, _ := c.Get("userID")
+		req.Owner, _ = userID.(string)
+	}
+
+	key, plaintext, err := h.apiKeyService.Create(ctx, &biz.CreateAPIKeyInput{
+		Name:   req.Name,
+		Owner:  req.Owner,
+		Scopes: req.Scopes,
+		TTL:    ttl,
+	})
+	if err != nil {
+		c.JSON(dto.GRPCErrorResponse(ctx, errcode.ToStatus(err)))
+		return
+	}
+
+	c.JSON(200, dto.SuccessResponse(utils.H{
+		"key":     plaintext,
+		"api_key": toAPIKeyView(key),
+	}))
+}
+
+func (h *APIKeyHandler) List(ctx context.Context, c *app.RequestContext) {
+	keys, err := h.apiKeyService.List(ctx, c.Query("owner"))
+	if err != nil {
+		c.JSON(dto.GRPCErrorResponse(ctx, errcode.ToStatus(err)))
+		return
+	}
+
+	views := make([]*APIKeyView, 0, len(keys))
+	for _, key := range keys {
+		views = append(views, toAPIKeyView(key))
+	}
+	c.JSON(200, dto.SuccessResponse(utils.H{
+		"api_keys": views,
+	}))
+}
+
+func (h *APIKeyHandler) Rotate(ctx context.Context, c *app.RequestContext) {
+	key, plaintext, err := h.apiKeyService.Rotate(ctx, c.Param("key_id"))
+	if err != nil {
+		c.JSON(dto.GRPCErrorResponse(ctx, errcode.ToStatus(err)))
+		return
+	}
+
+	c.JSON(200, dto.SuccessResponse(utils.H{
+		"key":     plaintext,
+		"api_key": toAPIKeyView(key),
+	}))
+}
+
+func (h *APIKeyHandler) Revoke(ctx context.Context, c *app.RequestContext) {
+	key, err := h.apiKeyService.Revoke(ctx, c.Param("key_id"))
+	if err != nil {
+		c.JSON(dto.GRPCErrorResponse(ctx, errcode.ToStatus(err)))
+		return
+	}
+
+	c.JSON(200, dto.SuccessResponse(utils.H{
+		"api_key": toAPIKeyView(key),
+	}))
+}
@@ -1,3 +1,33 @@
 package handler
 
-// Converter functions here
+import (
+	"time"
+
+	"youlingserv/internal/shared/model"
+)
+
+// APIKeyView 返回给调用方的 API key 信息，不含哈希
+type APIKeyView struct {
+	KeyID      string     `json:"key_id"`
+	Name       string     `json:"name"`
+	Owner      string     `json:"owner"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// toAPIKeyView Model → DTO
+func toAPIKeyView(key *model.APIKey) *APIKeyView {
+	return &APIKeyView{
+		KeyID:      key.KeyID,
+		Name:       key.Name,
+		Owner:      key.Owner,
+		Scopes:     key.ScopeList(),
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -27,7 +27,7 @@ type HelloRequest struct {
 func (h *HelloHandler) Handle(ctx context.Context, c *app.RequestContext) {
 	var req HelloRequest
 	if err := c.BindJSON(&req); err != nil {
-		c.JSON(400, dto.ErrorResponse(400, "invalid request: "+err.Error()))
+		c.JSON(400, dto.ErrorResponse(ctx, 400, "invalid request: "+err.Error()))
 		return
 	}
 
@@ -35,7 +35,7 @@ func (h *HelloHandler) Handle(ctx context.Context, c *app.RequestContext) {
 
 	message, err := h.helloService.SayHello(ctx, req.Name, userID.(string))
 	if err != nil {
-		c.JSON(500, dto.ErrorResponse(500, err.Error()))
+		c.JSON(500, dto.ErrorResponse(ctx, 500, err.Error()))
 		return
 	}
 
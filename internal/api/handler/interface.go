@@ -11,5 +11,25 @@ type HelloHandlerInterface interface {
 	Handle(ctx context.Context, c *app.RequestContext)
 }
 
+// APIKeyHandlerInterface API key 管理处理器接口
+type APIKeyHandlerInterface interface {
+	Create(ctx context.Context, c *app.RequestContext)
+	List(ctx context.Context, c *app.RequestContext)
+	Rotate(ctx context.Context, c *app.RequestContext)
+	Revoke(ctx context.Context, c *app.RequestContext)
+}
+
+// LogLevelHandlerInterface 日志级别管理处理器接口
+type LogLevelHandlerInterface interface {
+	Get(ctx context.Context, c *app.RequestContext)
+	Set(ctx context.Context, c *app.RequestContext)
+}
+
 // Ensure HelloHandler implements HelloHandlerInterface
 var _ HelloHandlerInterface = (*HelloHandler)(nil)
+
+// Ensure APIKeyHandler implements APIKeyHandlerInterface
+var _ APIKeyHandlerInterface = (*APIKeyHandler)(nil)
+
+// Ensure LogLevelHandler implements LogLevelHandlerInterface
+var _ LogLevelHandlerInterface = (*LogLevelHandler)(nil)
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"go.uber.org/zap"
+
+	"youlingserv/pkg/dto"
+	"youlingserv/pkg/log"
+)
+
+// LogLevelHandler 运行时查询与调整网关的日志级别，重启或配置文件中的 log.level 变化后以配置为准
+type LogLevelHandler struct{}
+
+func NewLogLevelHandler() LogLevelHandlerInterface {
+	return &LogLevelHandler{}
+}
+
+type LogLevelRequest struct {
+	Level string `json:"level"` // debug / info / warn / error
+}
+
+type LogLevelView struct {
+	Level string `json:"level"`
+}
+
+func (h *LogLevelHandler) Get(ctx context.Context, c *app.RequestContext) {
+	c.JSON(200, dto.SuccessResponse(&LogLevelView{Level: log.Level()}))
+}
+
+func (h *LogLevelHandler) Set(ctx context.Context, c *app.RequestContext) {
+	var req LogLevelRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(400, dto.ErrorResponse(ctx, 400, "invalid request: "+err.Error()))
+		return
+	}
+	previous := log.Level()
+	if err := log.SetLevel(req.Level); err != nil {
+		c.JSON(400, dto.ErrorResponse(ctx, 400, fmt.Sprintf("invalid level %q", req.Level)))
+		return
+	}
+	log.FromContext(ctx).Warn("Log level changed", zap.String("from", previous), zap.String("to", log.Level()))
+	c.JSON(200, dto.SuccessResponse(&LogLevelView{Level: log.Level()}))
+}
@@ -6,7 +6,8 @@ import (
 	"time"
 
 	"github.com/cloudwego/hertz/pkg/app"
-	"github.com/cloudwego/hertz/pkg/common/utils"
+
+	"youlingserv/pkg/dto"
 )
 
 type RateLimiter struct {
@@ -43,10 +44,7 @@ func (rl *RateLimiter) RateLimitMiddleware() app.HandlerFunc {
 		}
 
 		if len(rl.requests[clientIP]) >= rl.limit {
-			c.JSON(429, utils.H{
-				"code": 429,
-				"msg":  "too many requests",
-			})
+			c.JSON(429, dto.ErrorResponse(ctx, 429, "too many requests"))
 			c.Abort()
 			return
 		}
@@ -1,15 +1,50 @@
 package routes
 
 import (
+	"fmt"
+
 	"github.com/cloudwego/hertz/pkg/app/server"
 
+	"youlingserv/internal/api/gateway"
 	"youlingserv/internal/api/handler"
+	"youlingserv/internal/shared/auth"
 )
 
 // RegisterAPIRoutes 注册 API 路由
-func RegisterAPIRoutes(h *server.Hertz, helloHandler handler.HelloHandlerInterface) {
+func RegisterAPIRoutes(h *server.Hertz, helloHandler handler.HelloHandlerInterface, apiKeyHandler handler.APIKeyHandlerInterface, logLevelHandler handler.LogLevelHandlerInterface) {
 	v1 := h.Group("/api/v1")
 	{
 		v1.POST("/hello", helloHandler.Handle)
+
+		// 转发到 adhoc-server 的接口由 RegisterGatewayRoutes 按 proto 注解生成
+
+		// API key 管理
+		apiKeys := v1.Group("/admin/api-keys")
+		{
+			apiKeys.POST("", apiKeyHandler.Create)
+			apiKeys.GET("", apiKeyHandler.List)
+			apiKeys.POST("/:key_id/rotate", apiKeyHandler.Rotate)
+			apiKeys.POST("/:key_id/revoke", apiKeyHandler.Revoke)
+		}
+
+		// 运行时日志级别
+		v1.GET("/admin/log-level", logLevelHandler.Get)
+		v1.PUT("/admin/log-level", logLevelHandler.Set)
+	}
+}
+
+// RegisterGatewayRoutes 注册由 google.api.http 注解自动生成的 REST 路由
+// 每条路由沿用对应 RPC 的 (common.auth) 授权规则，RPC 未声明规则时路由保持默认拒绝
+func RegisterGatewayRoutes(h *server.Hertz, transcoder *gateway.Transcoder, rules *auth.RouteRules) error {
+	for _, r := range transcoder.Routes() {
+		rule, ok := auth.MethodRule(r.FullMethod)
+		if !ok {
+			continue
+		}
+		if err := rules.Set(r.HTTPMethod, r.Path, rule); err != nil {
+			return fmt.Errorf("routes: %s %s: %w", r.HTTPMethod, r.Path, err)
+		}
 	}
+	transcoder.Mount(h)
+	return nil
 }
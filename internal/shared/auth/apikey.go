@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// APIKeyScheme Authorization: ApiKey <key>
+const APIKeyScheme = "ApiKey"
+
+const (
+	apiKeyPrefix      = "yl_"
+	apiKeyIDBytes     = 8
+	apiKeySecretBytes = 32
+)
+
+// APIKeyVerifier 校验 API key，返回以 key 所有者身份调用、限定在 key 授权范围内的 Principal
+type APIKeyVerifier interface {
+	// VerifyAPIKey key 无效、已吊销或已过期时返回包装了 ErrInvalidToken 的错误
+	VerifyAPIKey(ctx context.Context, key string) (*Principal, error)
+}
+
+// apiKeyAuthenticator Authorization 为 ApiKey 时校验 API key，其余交给 base
+type apiKeyAuthenticator struct {
+	base Authenticator
+	keys APIKeyVerifier
+}
+
+var _ Authenticator = (*apiKeyAuthenticator)(nil)
+
+func (a *apiKeyAuthenticator) Authenticate(ctx context.Context, cred Credentials) (*Principal, error) {
+	scheme, key, ok := strings.Cut(strings.TrimSpace(cred.Authorization), " ")
+	if !ok || !strings.EqualFold(scheme, APIKeyScheme) {
+		return a.base.Authenticate(ctx, cred)
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, ErrMissingCredentials
+	}
+	return a.keys.VerifyAPIKey(ctx, key)
+}
+
+// GenerateAPIKey 生成新的 key ID 与 secret，完整的 key 为 FormatAPIKey(keyID, secret)
+func GenerateAPIKey() (keyID, secret string, err error) {
+	buf := make([]byte, apiKeyIDBytes+apiKeySecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("auth: failed to generate api key: %w", err)
+	}
+	return hex.EncodeToString(buf[:apiKeyIDBytes]), base64.RawURLEncoding.EncodeToString(buf[apiKeyIDBytes:]), nil
+}
+
+// FormatAPIKey 拼出交给调用方的完整 key：yl_<key ID>_<secret>
+func FormatAPIKey(keyID, secret string) string {
+	return apiKeyPrefix + keyID + "_" + secret
+}
+
+// ParseAPIKey FormatAPIKey 的逆操作
+func ParseAPIKey(key string) (keyID, secret string, ok bool) {
+	rest, ok := strings.CutPrefix(key, apiKeyPrefix)
+	if !ok {
+		return "", "", false
+	}
+	// key ID 是十六进制，不含 "_"；secret 是 base64url，可能含 "_"
+	keyID, secret, ok = strings.Cut(rest, "_")
+	if !ok || len(keyID) != 2*apiKeyIDBytes || secret == "" {
+		return "", "", false
+	}
+	return keyID, secret, true
+}
+
+// HashAPIKeySecret 计算 secret 的 bcrypt 哈希
+func HashAPIKeySecret(secret string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("auth: failed to hash api key: %w", err)
+	}
+	return string(hash), nil
+}
+
+// ValidateScope scope 的格式为 resource:action，两部分都不能为空，可以使用 * 通配
+func ValidateScope(scope string) error {
+	resource, action, ok := strings.Cut(scope, ":")
+	if !ok || resource == "" || action == "" || strings.ContainsAny(scope, " \t\n") {
+		return fmt.Errorf("invalid scope %q, want resource:action", scope)
+	}
+	return nil
+}
+
+// scopeAllows scopes 中是否有覆盖 resource / action 的一项
+func scopeAllows(scopes []string, resource, action string) bool {
+	for _, scope := range scopes {
+		r, a, ok := strings.Cut(scope, ":")
+		if ok && wildcardMatch(r, resource) && wildcardMatch(a, action) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"youlingserv/internal/shared/model"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/database"
+	"youlingserv/pkg/log"
+)
+
+const (
+	defaultLastUsedInterval = time.Minute
+	verifiedAPIKeyCacheSize = 10000
+)
+
+// ErrAPIKeyRevoked 对已吊销的 key 执行轮换
+var ErrAPIKeyRevoked = errors.New("api key is revoked")
+
+// APIKeyStore api_keys 表的读写与 API key 校验
+// bcrypt 校验较慢，校验通过的 secret 按 (key ID, secret 摘要) 记住对应的哈希，哈希变化（轮换）后自然失效；
+// 吊销与过期每次都以数据库为准，立即生效
+type APIKeyStore struct {
+	db               *gorm.DB
+	lastUsedInterval time.Duration
+
+	mu       sync.Mutex
+	verified map[[sha256.Size]byte]string
+}
+
+var _ APIKeyVerifier = (*APIKeyStore)(nil)
+
+// NewAPIKeyStore 创建 API key 存储
+func NewAPIKeyStore(db *gorm.DB, conf config.AuthConfig) *APIKeyStore {
+	s := &APIKeyStore{
+		db:               db,
+		lastUsedInterval: conf.APIKeys.LastUsedInterval,
+		verified:         make(map[[sha256.Size]byte]string),
+	}
+	if s.lastUsedInterval <= 0 {
+		s.lastUsedInterval = defaultLastUsedInterval
+	}
+	return s
+}
+
+// Create 为 key 生成 key ID 与 secret 并保存哈希，返回只展示这一次的完整 key
+func (s *APIKeyStore) Create(ctx context.Context, key *model.APIKey) (string, error) {
+	keyID, secret, err := GenerateAPIKey()
+	if err != nil {
+		return "", err
+	}
+	hash, err := HashAPIKeySecret(secret)
+	if err != nil {
+		return "", err
+	}
+	key.KeyID = keyID
+	key.Hash = hash
+	if err := database.Conn(ctx, s.db).Create(key).Error; err != nil {
+		return "", err
+	}
+	return FormatAPIKey(keyID, secret), nil
+}
+
+// Get 按 key ID 查询，不存在时返回 gorm.ErrRecordNotFound
+func (s *APIKeyStore) Get(ctx context.Context, keyID string) (*model.APIKey, error) {
+	var key model.APIKey
+	if err := database.Conn(ctx, s.db).Where("key_id = ?", keyID).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// List 按创建时间倒序列出 key，owner 为空时列出全部
+func (s *APIKeyStore) List(ctx context.Context, owner string) ([]*model.APIKey, error) {
+	query := database.Conn(ctx, s.db).Order("id DESC")
+	if owner != "" {
+		query = query.Where("owner = ?", owner)
+	}
+	var keys []*model.APIKey
+	if err := query.Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Rotate 为未吊销的 key 换一个新 secret，旧 secret 立即失效，返回更新后的记录与新的完整 key
+func (s *APIKeyStore) Rotate(ctx context.Context, keyID string) (*model.APIKey, string, error) {
+	_, secret, err := GenerateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	hash, err := HashAPIKeySecret(secret)
+	if err != nil {
+		return nil, "", err
+	}
+
+	result := database.Conn(ctx, s.db).Model(&model.APIKey{}).
+		Where("key_id = ? AND revoked_at IS NULL", keyID).
+		Update("hash", hash)
+	if result.Error != nil {
+		return nil, "", result.Error
+	}
+	key, err := s.Get(ctx, keyID)
+	if err != nil {
+		return nil, "", err
+	}
+	if result.RowsAffected == 0 {
+		return nil, "", ErrAPIKeyRevoked
+	}
+	return key, FormatAPIKey(keyID, secret), nil
+}
+
+// Revoke 吊销 key，重复吊销保留第一次的时间
+func (s *APIKeyStore) Revoke(ctx context.Context, keyID string) (*model.APIKey, error) {
+	err := database.Conn(ctx, s.db).Model(&model.APIKey{}).
+		Where("key_id = ? AND revoked_at IS NULL", keyID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, keyID)
+}
+
+// VerifyAPIKey 校验 key 并按 last_used_interval 更新最近使用时间
+func (s *APIKeyStore) VerifyAPIKey(ctx context.Context, raw string) (*Principal, error) {
+	keyID, secret, ok := ParseAPIKey(raw)
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed api key", ErrInvalidToken)
+	}
+
+	key, err := s.Get(ctx, keyID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: unknown api key", ErrInvalidToken)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to load api key: %w", err)
+	}
+
+	now := time.Now()
+	switch {
+	case key.RevokedAt != nil:
+		return nil, fmt.Errorf("%w: api key %s is revoked", ErrInvalidToken, keyID)
+	case key.Expired(now):
+		return nil, fmt.Errorf("%w: api key %s expired", ErrInvalidToken, keyID)
+	case !s.checkSecret(keyID, secret, key.Hash):
+		return nil, fmt.Errorf("%w: api key %s does not match", ErrInvalidToken, keyID)
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= s.lastUsedInterval {
+		s.touch(ctx, key.ID, now)
+	}
+
+	return &Principal{
+		Subject:  key.Owner,
+		APIKeyID: key.KeyID,
+		Scopes:   key.ScopeList(),
+	}, nil
+}
+
+// checkSecret 比对 secret 与哈希，命中已校验缓存时跳过 bcrypt
+func (s *APIKeyStore) checkSecret(keyID, secret, hash string) bool {
+	digest := sha256.Sum256([]byte(keyID + "\x00" + secret))
+
+	s.mu.Lock()
+	cached, ok := s.verified[digest]
+	s.mu.Unlock()
+	if ok && cached == hash {
+		return true
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(secret)) != nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.verified) >= verifiedAPIKeyCacheSize {
+		s.verified = make(map[[sha256.Size]byte]string)
+	}
+	s.verified[digest] = hash
+	return true
+}
+
+// touch 更新最近使用时间，失败只记录日志，不影响本次认证
+func (s *APIKeyStore) touch(ctx context.Context, id int64, now time.Time) {
+	err := database.Conn(ctx, s.db).Model(&model.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, now.Add(-s.lastUsedInterval)).
+		UpdateColumn("last_used_at", now).Error
+	if err != nil && !errors.Is(err, context.Canceled) {
+		log.FromContext(ctx).Warn("Failed to update last_used_at of api key", zap.Int64("id", id), zap.Error(err))
+	}
+}
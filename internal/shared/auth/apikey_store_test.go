@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"youlingserv/internal/shared/migrations"
+	"youlingserv/internal/shared/model"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/database"
+	"youlingserv/pkg/migrate"
+)
+
+func newTestAPIKeyStore(t *testing.T) (*APIKeyStore, *gorm.DB) {
+	t.Helper()
+	db, err := database.Open(&database.Config{Driver: database.DriverSQLite, Database: ":memory:"})
+	require.NoError(t, err)
+	sqlDB, _ := db.DB()
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	ms, err := migrations.Load(database.DriverSQLite)
+	require.NoError(t, err)
+	_, err = migrate.New(db, ms).Up(context.Background(), 0)
+	require.NoError(t, err)
+
+	return NewAPIKeyStore(db, config.AuthConfig{}), db
+}
+
+func TestAPIKeyStore_Lifecycle(t *testing.T) {
+	store, db := newTestAPIKeyStore(t)
+	ctx := context.Background()
+	authenticator := &apiKeyAuthenticator{base: &headerAuthenticator{}, keys: store}
+
+	key := &model.APIKey{Name: "nightly export", Owner: "batch", Scopes: "adhoc/access-logs:list adhoc/greetings:*"}
+	plaintext, err := store.Create(ctx, key)
+	require.NoError(t, err)
+	assert.NotContains(t, key.Hash, plaintext, "only the hash is stored")
+
+	p, err := authenticator.Authenticate(ctx, Credentials{Authorization: "ApiKey " + plaintext})
+	require.NoError(t, err)
+	assert.Equal(t, &Principal{Subject: "batch", APIKeyID: key.KeyID, Scopes: []string{"adhoc/access-logs:list", "adhoc/greetings:*"}}, p)
+
+	stored, err := store.Get(ctx, key.KeyID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.LastUsedAt)
+
+	// 其他 scheme 仍走原认证方式
+	p, err = authenticator.Authenticate(ctx, Credentials{UserID: "user123"})
+	require.NoError(t, err)
+	assert.Empty(t, p.APIKeyID)
+
+	_, err = store.VerifyAPIKey(ctx, plaintext+"x")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, err = store.VerifyAPIKey(ctx, "not-a-key")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// 轮换后旧 key 立即失效
+	_, rotated, err := store.Rotate(ctx, key.KeyID)
+	require.NoError(t, err)
+	_, err = store.VerifyAPIKey(ctx, plaintext)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, err = store.VerifyAPIKey(ctx, rotated)
+	require.NoError(t, err)
+
+	revoked, err := store.Revoke(ctx, key.KeyID)
+	require.NoError(t, err)
+	require.NotNil(t, revoked.RevokedAt)
+	_, err = store.VerifyAPIKey(ctx, rotated)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, _, err = store.Rotate(ctx, key.KeyID)
+	assert.ErrorIs(t, err, ErrAPIKeyRevoked)
+	_, _, err = store.Rotate(ctx, "0000000000000000")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	expired := &model.APIKey{Name: "old", Owner: "batch", Scopes: "*:*"}
+	plaintext, err = store.Create(ctx, expired)
+	require.NoError(t, err)
+	require.NoError(t, db.Model(expired).Update("expires_at", time.Now().Add(-time.Second)).Error)
+	_, err = store.VerifyAPIKey(ctx, plaintext)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestPermissionChecker_APIKeyScopes(t *testing.T) {
+	checker := NewPermissionChecker(&mockAuthClient{})
+	ctx := WithPrincipal(context.Background(), &Principal{
+		Subject:  "batch",
+		APIKeyID: "0123456789abcdef",
+		Scopes:   []string{"adhoc/access-logs:list", "adhoc/greetings:*"},
+	})
+
+	assert.NoError(t, checker.CheckAccess(ctx, "batch", "adhoc/access-logs", "list"))
+	assert.NoError(t, checker.CheckAccess(ctx, "batch", "adhoc/greetings", "goodbye"))
+
+	err := checker.CheckAccess(ctx, "batch", "adhoc/access-logs", "watch")
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	assert.Contains(t, err.Error(), "no scope for adhoc/access-logs:watch")
+}
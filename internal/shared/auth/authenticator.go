@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/log"
+)
+
+// 认证模式
+const (
+	ModeJWT    = "jwt"    // 校验 Authorization: Bearer <token>
+	ModeHeader = "header" // 直接信任 X-User-ID / user-id，仅限本地开发
+)
+
+var (
+	ErrMissingCredentials = errors.New("missing credentials")
+	ErrInvalidToken       = errors.New("invalid token")
+)
+
+// Credentials 请求携带的凭证，HTTP 从 header、gRPC 从 metadata 中提取
+type Credentials struct {
+	Authorization string // Authorization / authorization
+	UserID        string // X-User-ID / user-id，仅 header 模式使用
+	Peer          string // mTLS 客户端证书标识的调用方服务，由中间件从连接中读取
+}
+
+// Authenticator 识别调用方，HTTP 与 gRPC 鉴权共用
+type Authenticator interface {
+	// Authenticate 返回调用方；凭证缺失返回 ErrMissingCredentials，校验失败返回包装了 ErrInvalidToken 的错误
+	Authenticate(ctx context.Context, cred Credentials) (*Principal, error)
+}
+
+type jwtAuthenticator struct {
+	verifier *TokenVerifier
+}
+
+type headerAuthenticator struct{}
+
+// peerAuthenticator 记录调用方服务；没有用户凭证时，只有 auth.mtls.service_principals 中的服务可以以自身身份调用
+type peerAuthenticator struct {
+	base     Authenticator
+	services map[string]struct{}
+}
+
+var (
+	_ Authenticator = (*jwtAuthenticator)(nil)
+	_ Authenticator = (*headerAuthenticator)(nil)
+	_ Authenticator = (*peerAuthenticator)(nil)
+)
+
+// NewAuthenticator 按 conf.Mode 创建认证器，mode 为空时使用 jwt
+// keys 非空时另外接受 Authorization: ApiKey <key>，与 mode 无关
+func NewAuthenticator(conf config.AuthConfig, keys APIKeyVerifier) (Authenticator, func(), error) {
+	base, cleanup, err := newModeAuthenticator(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	if keys != nil {
+		base = &apiKeyAuthenticator{base: base, keys: keys}
+	}
+	services := make(map[string]struct{}, len(conf.MTLS.ServicePrincipals))
+	for _, id := range conf.MTLS.ServicePrincipals {
+		services[id] = struct{}{}
+	}
+	return &peerAuthenticator{base: base, services: services}, cleanup, nil
+}
+
+func newModeAuthenticator(conf config.AuthConfig) (Authenticator, func(), error) {
+	switch conf.Mode {
+	case "", ModeJWT:
+		verifier, cleanup, err := NewTokenVerifier(conf.JWT)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &jwtAuthenticator{verifier: verifier}, cleanup, nil
+	case ModeHeader:
+		log.GetLogger().Warn("Auth mode is \"header\": caller identity is taken from X-User-ID / user-id without verification, use only for local development")
+		return &headerAuthenticator{}, func() {}, nil
+	default:
+		return nil, nil, fmt.Errorf("auth: unknown mode %q", conf.Mode)
+	}
+}
+
+func (a *jwtAuthenticator) Authenticate(ctx context.Context, cred Credentials) (*Principal, error) {
+	token, ok := bearerToken(cred.Authorization)
+	if !ok {
+		return nil, ErrMissingCredentials
+	}
+	p, err := a.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return p, nil
+}
+
+func (a *headerAuthenticator) Authenticate(ctx context.Context, cred Credentials) (*Principal, error) {
+	if cred.UserID == "" {
+		return nil, ErrMissingCredentials
+	}
+	return &Principal{Subject: cred.UserID}, nil
+}
+
+func (a *peerAuthenticator) Authenticate(ctx context.Context, cred Credentials) (*Principal, error) {
+	p, err := a.base.Authenticate(ctx, cred)
+	if errors.Is(err, ErrMissingCredentials) && cred.Peer != "" {
+		if _, ok := a.services[cred.Peer]; ok {
+			return &Principal{Subject: cred.Peer, Peer: cred.Peer}, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.Peer = cred.Peer
+	return p, nil
+}
+
+// bearerToken 解析 "Bearer <token>"，scheme 不区分大小写
+func bearerToken(authorization string) (string, bool) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
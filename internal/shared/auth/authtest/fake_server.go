@@ -0,0 +1,98 @@
+// Package authtest 本地的 auth.v1.PermissionService 实现，用于离线端到端测试和本地开发
+package authtest
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authv1 "youlingserv/gen/go/auth/v1"
+	"youlingserv/internal/shared/auth"
+)
+
+// FakePermissionServer 用任意 AuthClient（通常是 RBACClient）判定权限，可注入延迟和故障
+type FakePermissionServer struct {
+	authv1.UnimplementedPermissionServiceServer
+
+	decider auth.AuthClient
+	calls   atomic.Int64
+
+	mu       sync.Mutex
+	delay    time.Duration
+	failures int
+	failCode codes.Code
+}
+
+// NewFakePermissionServer decider 负责实际判定，请求中的角色与租户以 Principal 形式放入 ctx
+func NewFakePermissionServer(decider auth.AuthClient) *FakePermissionServer {
+	return &FakePermissionServer{decider: decider}
+}
+
+func (s *FakePermissionServer) Check(ctx context.Context, req *authv1.CheckRequest) (*authv1.CheckResponse, error) {
+	s.calls.Add(1)
+
+	s.mu.Lock()
+	delay := s.delay
+	var injected error
+	if s.failures > 0 {
+		s.failures--
+		injected = status.Error(s.failCode, "injected failure")
+	}
+	s.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-time.After(delay):
+		}
+	}
+	if injected != nil {
+		return nil, injected
+	}
+
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: req.GetSubject(), Roles: req.GetRoles(), Tenant: req.GetTenant()})
+	d, err := s.decider.CheckPermission(ctx, req.GetSubject(), req.GetResource(), req.GetAction())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &authv1.CheckResponse{Allowed: d.Allowed, Reason: d.Reason}, nil
+}
+
+// FailNext 之后的 n 次调用返回 code 错误
+func (s *FakePermissionServer) FailNext(n int, code codes.Code) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = n
+	s.failCode = code
+}
+
+// SetDelay 每次调用前等待 d，用于模拟超时
+func (s *FakePermissionServer) SetDelay(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delay = d
+}
+
+// Calls 累计收到的调用次数
+func (s *FakePermissionServer) Calls() int64 {
+	return s.calls.Load()
+}
+
+// Serve 在 addr 上启动 gRPC 服务，返回实际监听地址（addr 端口为 0 时由系统分配）与停止函数
+func Serve(addr string, srv authv1.PermissionServiceServer) (string, func(), error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", nil, err
+	}
+	server := grpc.NewServer()
+	authv1.RegisterPermissionServiceServer(server, srv)
+	go func() { _ = server.Serve(lis) }()
+	return lis.Addr().String(), server.Stop, nil
+}
@@ -3,8 +3,28 @@ package auth
 import (
 	"context"
 	"errors"
+	"fmt"
 )
 
+// ErrPermissionDenied 所有拒绝访问错误都可以用 errors.Is 判断
+var ErrPermissionDenied = errors.New("permission denied")
+
+// DeniedError 拒绝访问的结构化原因
+type DeniedError struct {
+	Subject  string
+	Resource string
+	Action   string
+	Reason   string
+}
+
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("permission denied: %s may not %s %s: %s", e.Subject, e.Action, e.Resource, e.Reason)
+}
+
+func (e *DeniedError) Unwrap() error {
+	return ErrPermissionDenied
+}
+
 type PermissionChecker struct {
 	client AuthClient
 }
@@ -15,13 +35,29 @@ func NewPermissionChecker(client AuthClient) *PermissionChecker {
 	}
 }
 
+// CheckAccess 拒绝时返回 *DeniedError，判定过程出错时返回原始错误
+// 通过 API key 认证的调用方先按 key 的 scopes 判定，scopes 覆盖时再按 key 所有者的权限判定
 func (c *PermissionChecker) CheckAccess(ctx context.Context, userID, resource, action string) error {
-	allowed, err := c.client.CheckPermission(ctx, userID, resource, action)
+	if p, ok := PrincipalFromContext(ctx); ok && p.Subject == userID && p.APIKeyID != "" && !scopeAllows(p.Scopes, resource, action) {
+		return &DeniedError{
+			Subject:  userID,
+			Resource: resource,
+			Action:   action,
+			Reason:   fmt.Sprintf("api key %s has no scope for %s:%s", p.APIKeyID, resource, action),
+		}
+	}
+
+	decision, err := c.client.CheckPermission(ctx, userID, resource, action)
 	if err != nil {
 		return err
 	}
-	if !allowed {
-		return errors.New("permission denied")
+	if !decision.Allowed {
+		return &DeniedError{
+			Subject:  userID,
+			Resource: resource,
+			Action:   action,
+			Reason:   decision.Reason,
+		}
 	}
 	return nil
 }
@@ -3,21 +3,44 @@ package auth
 import (
 	"context"
 	"errors"
+
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/log"
 )
 
+// Decision 一次权限判定的结果
+type Decision struct {
+	Allowed bool
+	Reason  string // 允许时为命中的角色与授权，拒绝时为拒绝原因
+}
+
 type AuthClient interface {
-	CheckPermission(ctx context.Context, userID, resource, action string) (bool, error)
+	CheckPermission(ctx context.Context, userID, resource, action string) (Decision, error)
 }
 
 type mockAuthClient struct{}
 
-func NewAuthClient() AuthClient {
-	return &mockAuthClient{}
+// NewAuthClient 按配置选择实现：auth.remote.target 非空时调用外部权限服务，
+// 其次使用 auth.rbac.policy_file 中的 RBAC 策略，都未配置时放行所有已认证调用方
+func NewAuthClient(conf config.AuthConfig) (AuthClient, func(), error) {
+	switch {
+	case conf.Remote.Target != "":
+		return NewRemoteClient(conf.Remote)
+	case conf.RBAC.PolicyFile != "":
+		client, err := NewRBACClient(conf.RBAC)
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, func() {}, nil
+	default:
+		log.GetLogger().Warn("No permission service or RBAC policy configured, every authenticated caller is allowed")
+		return &mockAuthClient{}, func() {}, nil
+	}
 }
 
-func (m *mockAuthClient) CheckPermission(ctx context.Context, userID, resource, action string) (bool, error) {
+func (m *mockAuthClient) CheckPermission(ctx context.Context, userID, resource, action string) (Decision, error) {
 	if userID == "" {
-		return false, errors.New("user ID is required")
+		return Decision{}, errors.New("user ID is required")
 	}
-	return true, nil
+	return Decision{Allowed: true, Reason: "no policy configured"}, nil
 }
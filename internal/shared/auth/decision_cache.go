@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+const defaultDecisionCacheSize = 10000
+
+// decisionCache 权限判定结果的内存缓存，条目在 ttl 后过期，策略变化时整体清空
+type decisionCache struct {
+	ttl     time.Duration
+	maxSize int
+
+	mu      sync.Mutex
+	entries map[string]cachedDecision
+}
+
+type cachedDecision struct {
+	decision  Decision
+	expiresAt time.Time
+}
+
+// newDecisionCache ttl <= 0 时关闭缓存
+func newDecisionCache(ttl time.Duration, maxSize int) *decisionCache {
+	if maxSize <= 0 {
+		maxSize = defaultDecisionCacheSize
+	}
+	return &decisionCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		entries: make(map[string]cachedDecision),
+	}
+}
+
+func (c *decisionCache) get(key string) (Decision, bool) {
+	if c.ttl <= 0 {
+		return Decision{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return Decision{}, false
+	}
+	if time.Now().After(e.expiresAt) {
+		delete(c.entries, key)
+		return Decision{}, false
+	}
+	return e.decision, true
+}
+
+func (c *decisionCache) put(key string, d Decision) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.entries) >= c.maxSize {
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		// 全部未过期时整体清空，避免为淘汰维护额外的顺序结构
+		if len(c.entries) >= c.maxSize {
+			c.entries = make(map[string]cachedDecision)
+		}
+	}
+	c.entries[key] = cachedDecision{decision: d, expiresAt: now.Add(c.ttl)}
+}
+
+// purge 清空缓存，策略重新加载后调用
+func (c *decisionCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]cachedDecision)
+}
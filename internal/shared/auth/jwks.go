@@ -0,0 +1,308 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"youlingserv/pkg/log"
+)
+
+const (
+	defaultJWKSRefreshInterval = 5 * time.Minute
+	// jwksMinRefreshInterval 遇到未知 kid 时按需刷新的最小间隔，防止伪造 kid 打满 JWKS 端点
+	jwksMinRefreshInterval = 30 * time.Second
+	jwksFetchTimeout       = 10 * time.Second
+	jwksMaxSize            = 1 << 20
+)
+
+var errKeyNotFound = errors.New("signing key not found")
+
+// jwk JWKS 中的一个密钥，只解析签名校验需要的字段
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	K   string `json:"k"`
+}
+
+// verifyKey 解析后的校验密钥：[]byte（oct）、*rsa.PublicKey 或 ed25519.PublicKey
+type verifyKey struct {
+	kid string
+	alg string
+	key interface{}
+}
+
+// KeySet JWKS 密钥集合，从本地文件或 URL 加载
+// 后台定期重新加载以支持密钥轮换；遇到未知 kid 时按需刷新，加载失败保留旧密钥
+type KeySet struct {
+	source   string
+	load     func(ctx context.Context) ([]byte, error)
+	interval time.Duration
+
+	mu          sync.RWMutex
+	keys        []verifyKey
+	lastRefresh time.Time
+
+	// refreshMu 串行化刷新，并发的未知 kid 只触发一次加载
+	refreshMu sync.Mutex
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// NewKeySet 加载 JWKS 并启动定期刷新，file 与 url 二选一，首次加载失败直接返回错误
+func NewKeySet(file, url string, interval time.Duration) (*KeySet, error) {
+	s := &KeySet{
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+	switch {
+	case file != "" && url != "":
+		return nil, errors.New("jwks: jwks_file and jwks_url are mutually exclusive")
+	case file != "":
+		s.source = file
+		s.load = func(context.Context) ([]byte, error) { return os.ReadFile(file) }
+	case url != "":
+		s.source = url
+		s.load = func(ctx context.Context) ([]byte, error) { return fetchJWKS(ctx, url) }
+	default:
+		return nil, errors.New("jwks: no source configured")
+	}
+	if s.interval <= 0 {
+		s.interval = defaultJWKSRefreshInterval
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+	defer cancel()
+	if err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	s.wg.Add(1)
+	go s.refreshLoop()
+	return s, nil
+}
+
+// Refresh 立即重新加载 JWKS，失败时保留当前密钥
+func (s *KeySet) Refresh(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+	return s.refresh(ctx)
+}
+
+// refresh 调用方持有 refreshMu
+func (s *KeySet) refresh(ctx context.Context) error {
+	data, err := s.load(ctx)
+	if err != nil {
+		return fmt.Errorf("jwks: load %s: %w", s.source, err)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return fmt.Errorf("jwks: parse %s: %w", s.source, err)
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.lastRefresh = time.Now()
+	s.mu.Unlock()
+	return nil
+}
+
+// Key 按 kid 与签名算法查找密钥，找不到时按需刷新一次再查
+func (s *KeySet) Key(ctx context.Context, kid, alg string) (interface{}, error) {
+	if key, ok := s.lookup(kid, alg); ok {
+		return key, nil
+	}
+
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+	// 等锁期间其他请求可能已经刷新过
+	if key, ok := s.lookup(kid, alg); ok {
+		return key, nil
+	}
+	s.mu.RLock()
+	recent := time.Since(s.lastRefresh) < jwksMinRefreshInterval
+	s.mu.RUnlock()
+	if recent {
+		return nil, errKeyNotFound
+	}
+	if err := s.refresh(ctx); err != nil {
+		log.FromContext(ctx).Warn("Failed to refresh JWKS for unknown kid", zap.String("kid", kid), zap.Error(err))
+		return nil, errKeyNotFound
+	}
+	if key, ok := s.lookup(kid, alg); ok {
+		return key, nil
+	}
+	return nil, errKeyNotFound
+}
+
+// lookup kid 为空时只在恰好有一个类型匹配的密钥时返回，避免多把密钥逐个尝试
+func (s *KeySet) lookup(kid, alg string) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var found interface{}
+	matches := 0
+	for _, k := range s.keys {
+		if kid != "" && k.kid != kid {
+			continue
+		}
+		if (k.alg != "" && k.alg != alg) || !keyMatchesAlg(k.key, alg) {
+			continue
+		}
+		found = k.key
+		matches++
+	}
+	return found, matches == 1
+}
+
+func (s *KeySet) refreshLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+			if err := s.Refresh(ctx); err != nil {
+				log.GetLogger().Warn("Failed to refresh JWKS, keeping previous keys", zap.Error(err))
+			}
+			cancel()
+		}
+	}
+}
+
+// Close 停止后台刷新，可重复调用
+func (s *KeySet) Close() {
+	s.once.Do(func() {
+		close(s.stop)
+		s.wg.Wait()
+	})
+}
+
+func fetchJWKS(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, jwksMaxSize))
+}
+
+// parseJWKS 解析 JWKS，跳过非签名用途和不支持的密钥类型
+func parseJWKS(data []byte) ([]verifyKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make([]verifyKey, 0, len(set.Keys))
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %d (kid %q): %w", i, k.Kid, err)
+		}
+		if key == nil {
+			continue
+		}
+		keys = append(keys, verifyKey{kid: k.Kid, alg: k.Alg, key: key})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no usable signing keys")
+	}
+	return keys, nil
+}
+
+// publicKey 不支持的 kty / crv 返回 nil
+func (k *jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeSegment(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("n: %w", err)
+		}
+		e, err := decodeSegment(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("e: %w", err)
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() < 3 || exp.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, nil
+		}
+		x, err := decodeSegment(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("x: %w", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	case "oct":
+		secret, err := decodeSegment(k.K)
+		if err != nil {
+			return nil, fmt.Errorf("k: %w", err)
+		}
+		return secret, nil
+	default:
+		return nil, nil
+	}
+}
+
+func decodeSegment(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("missing")
+	}
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+// keyMatchesAlg 密钥类型是否能用于该签名算法
+func keyMatchesAlg(key interface{}, alg string) bool {
+	switch key.(type) {
+	case []byte:
+		return alg == "HS256" || alg == "HS384" || alg == "HS512"
+	case *rsa.PublicKey:
+		return alg == "RS256" || alg == "RS384" || alg == "RS512"
+	case ed25519.PublicKey:
+		return alg == "EdDSA"
+	}
+	return false
+}
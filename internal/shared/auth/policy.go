@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// bindAll 绑定到所有已认证调用方的 subject
+const bindAll = "*"
+
+// PolicyDocument RBAC 策略文件
+//
+//	roles:
+//	  - name: viewer
+//	    grants:
+//	      - resource: "api"
+//	        actions: ["access"]
+//	bindings:
+//	  - subject: user123
+//	    roles: [viewer]
+//
+// 使用列表而不是 map，因为 viper 会把 map 的键转成小写
+type PolicyDocument struct {
+	Roles    []RoleDef    `mapstructure:"roles"`
+	Bindings []BindingDef `mapstructure:"bindings"`
+}
+
+// RoleDef 角色及其授权
+type RoleDef struct {
+	Name   string     `mapstructure:"name"`
+	Grants []GrantDef `mapstructure:"grants"`
+}
+
+// GrantDef 一条授权，resource 与 actions 中的 * 匹配任意字符序列
+type GrantDef struct {
+	Resource string   `mapstructure:"resource"`
+	Actions  []string `mapstructure:"actions"`
+}
+
+// BindingDef 把角色绑定到 subject，subject 为 * 时绑定到所有已认证调用方
+type BindingDef struct {
+	Subject string   `mapstructure:"subject"`
+	Roles   []string `mapstructure:"roles"`
+}
+
+// policy 编译后的策略，只读，重新加载时整体替换
+type policy struct {
+	version  uint64
+	roles    map[string][]GrantDef
+	bindings map[string][]string
+}
+
+// compilePolicy 校验并编译策略文件，引用未定义角色的绑定视为错误
+func compilePolicy(doc PolicyDocument) (*policy, error) {
+	p := &policy{
+		roles:    make(map[string][]GrantDef, len(doc.Roles)),
+		bindings: make(map[string][]string, len(doc.Bindings)),
+	}
+
+	var errs []error
+	for i, role := range doc.Roles {
+		if role.Name == "" {
+			errs = append(errs, fmt.Errorf("roles[%d]: missing name", i))
+			continue
+		}
+		if _, ok := p.roles[role.Name]; ok {
+			errs = append(errs, fmt.Errorf("roles[%d]: duplicate role %q", i, role.Name))
+			continue
+		}
+		for j, grant := range role.Grants {
+			if grant.Resource == "" || len(grant.Actions) == 0 {
+				errs = append(errs, fmt.Errorf("role %q grants[%d]: resource and actions are required", role.Name, j))
+			}
+		}
+		p.roles[role.Name] = role.Grants
+	}
+	for i, binding := range doc.Bindings {
+		if binding.Subject == "" {
+			errs = append(errs, fmt.Errorf("bindings[%d]: missing subject", i))
+			continue
+		}
+		for _, name := range binding.Roles {
+			if _, ok := p.roles[name]; !ok {
+				errs = append(errs, fmt.Errorf("bindings[%d]: subject %q bound to undefined role %q", i, binding.Subject, name))
+			}
+		}
+		p.bindings[binding.Subject] = append(p.bindings[binding.Subject], binding.Roles...)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// rolesOf subject 的角色：策略中的绑定、* 绑定与调用方自带的角色（如 JWT roles），去重排序
+func (p *policy) rolesOf(subject string, extra []string) []string {
+	seen := make(map[string]struct{})
+	var roles []string
+	for _, group := range [][]string{p.bindings[subject], p.bindings[bindAll], extra} {
+		for _, r := range group {
+			if _, ok := seen[r]; ok {
+				continue
+			}
+			seen[r] = struct{}{}
+			roles = append(roles, r)
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// evaluate 任一角色有匹配的授权即允许
+func (p *policy) evaluate(subject string, extraRoles []string, resource, action string) Decision {
+	roles := p.rolesOf(subject, extraRoles)
+	if len(roles) == 0 {
+		return Decision{Reason: fmt.Sprintf("no roles bound to subject %q", subject)}
+	}
+
+	for _, role := range roles {
+		for _, grant := range p.roles[role] {
+			if !wildcardMatch(grant.Resource, resource) {
+				continue
+			}
+			for _, a := range grant.Actions {
+				if wildcardMatch(a, action) {
+					return Decision{
+						Allowed: true,
+						Reason:  fmt.Sprintf("role %q grants %s on %s", role, a, grant.Resource),
+					}
+				}
+			}
+		}
+	}
+	return Decision{Reason: fmt.Sprintf("none of roles [%s] grants %s on %s", strings.Join(roles, ", "), action, resource)}
+}
+
+// wildcardMatch * 匹配任意长度（含 0）的任意字符，包括 /，其余字符精确匹配
+func wildcardMatch(pattern, s string) bool {
+	if !strings.Contains(pattern, "*") {
+		return pattern == s
+	}
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, last)
+}
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+)
+
+// Principal 认证通过的调用方
+type Principal struct {
+	Subject string   // 用户 ID，JWT 的 sub
+	Roles   []string // 角色
+	Tenant  string   // 租户
+
+	APIKeyID string   // 通过 API key 认证时为 key ID
+	Scopes   []string // API key 的授权范围，只在这些 resource:action 之内按 Subject 判定权限
+
+	Peer string // mTLS 客户端证书标识的调用方服务（SPIFFE ID 或 CN），名单内的服务没有用户凭证时即为 Subject
+}
+
+type principalKey struct{}
+
+// WithPrincipal 把调用方写入 ctx
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext 读取 ctx 中的调用方，未认证时返回 false
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// CertificateIdentity 证书标识的服务：优先取 spiffe:// 开头的 URI SAN，否则取 Subject CN
+func CertificateIdentity(cert *x509.Certificate) string {
+	for _, uri := range cert.URIs {
+		if uri.Scheme == "spiffe" {
+			return uri.String()
+		}
+	}
+	return cert.Subject.CommonName
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/log"
+)
+
+// RBACClient 基于策略文件的 AuthClient：subject → 角色 → resource/action 授权
+// 策略文件变化时自动重新加载，新策略校验失败则保留旧策略；判定结果按 TTL 缓存
+type RBACClient struct {
+	policy  atomic.Pointer[policy]
+	version atomic.Uint64
+	cache   *decisionCache
+}
+
+var _ AuthClient = (*RBACClient)(nil)
+
+// NewRBACClient 加载策略文件并监听变化，首次加载或校验失败返回错误
+func NewRBACClient(conf config.RBACConfig) (*RBACClient, error) {
+	c := &RBACClient{
+		cache: newDecisionCache(conf.CacheTTL, conf.CacheSize),
+	}
+
+	doc, err := config.WatchFile(conf.PolicyFile, c.reload)
+	if err != nil {
+		return nil, fmt.Errorf("rbac: load policy %s: %w", conf.PolicyFile, err)
+	}
+	p, err := compilePolicy(doc)
+	if err != nil {
+		return nil, fmt.Errorf("rbac: invalid policy %s: %w", conf.PolicyFile, err)
+	}
+	// 监听已开始，文件若在此期间变化，reload 存入的新策略优先
+	p.version = c.version.Add(1)
+	c.policy.CompareAndSwap(nil, p)
+	return c, nil
+}
+
+// reload 文件变化时由 config.WatchFile 回调
+func (c *RBACClient) reload(doc PolicyDocument) error {
+	p, err := compilePolicy(doc)
+	if err != nil {
+		return err
+	}
+	c.store(p)
+	c.cache.purge()
+	log.GetLogger().Info("RBAC policy reloaded", zap.Int("roles", len(p.roles)), zap.Int("bindings", len(p.bindings)))
+	return nil
+}
+
+// store 缓存键带上策略版本，重新加载期间用旧策略算出的结果不会被新策略命中
+func (c *RBACClient) store(p *policy) {
+	p.version = c.version.Add(1)
+	c.policy.Store(p)
+}
+
+// CheckPermission ctx 中的 Principal 与 userID 一致时，其自带的角色（如 JWT roles）一并参与判定
+func (c *RBACClient) CheckPermission(ctx context.Context, userID, resource, action string) (Decision, error) {
+	if userID == "" {
+		return Decision{Reason: "missing subject"}, nil
+	}
+
+	var extraRoles []string
+	if p, ok := PrincipalFromContext(ctx); ok && p.Subject == userID {
+		extraRoles = p.Roles
+	}
+
+	p := c.policy.Load()
+	key := strings.Join([]string{strconv.FormatUint(p.version, 10), userID, strings.Join(extraRoles, ","), resource, action}, "\x00")
+	if d, ok := c.cache.get(key); ok {
+		return d, nil
+	}
+	d := p.evaluate(userID, extraRoles, resource, action)
+	c.cache.put(key, d)
+	return d, nil
+}
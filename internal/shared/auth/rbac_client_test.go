@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"youlingserv/pkg/config"
+)
+
+const testPolicy = `
+roles:
+  - name: admin
+    grants:
+      - resource: "*"
+        actions: ["*"]
+  - name: reader
+    grants:
+      - resource: "/adhoc.v1.AdhocService/List*"
+        actions: [call]
+      - resource: api
+        actions: [access]
+bindings:
+  - subject: Alice
+    roles: [admin]
+  - subject: "*"
+    roles: [reader]
+`
+
+func newTestRBACClient(t *testing.T, policy string, ttl time.Duration) (*RBACClient, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.yml")
+	require.NoError(t, os.WriteFile(path, []byte(policy), 0o600))
+	c, err := NewRBACClient(config.RBACConfig{PolicyFile: path, CacheTTL: ttl})
+	require.NoError(t, err)
+	return c, path
+}
+
+func TestRBACClient_Evaluate(t *testing.T) {
+	c, _ := newTestRBACClient(t, testPolicy, 0)
+	checker := NewPermissionChecker(c)
+	ctx := context.Background()
+
+	// 绑定区分大小写，Alice 是管理员
+	assert.NoError(t, checker.CheckAccess(ctx, "Alice", "/adhoc.v1.AdhocService/Hello", "call"))
+	assert.NoError(t, checker.CheckAccess(ctx, "bob", "/adhoc.v1.AdhocService/ListAccessLogs", "call"))
+	assert.NoError(t, checker.CheckAccess(ctx, "bob", "api", "access"))
+
+	err := checker.CheckAccess(ctx, "bob", "/adhoc.v1.AdhocService/Hello", "call")
+	var denied *DeniedError
+	require.True(t, errors.As(err, &denied))
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	assert.Equal(t, "bob", denied.Subject)
+	assert.Equal(t, "/adhoc.v1.AdhocService/Hello", denied.Resource)
+	assert.Contains(t, denied.Reason, "none of roles [reader]")
+
+	// JWT 携带的角色与策略绑定合并
+	jwtCtx := WithPrincipal(ctx, &Principal{Subject: "bob", Roles: []string{"admin"}})
+	assert.NoError(t, checker.CheckAccess(jwtCtx, "bob", "/adhoc.v1.AdhocService/Hello", "call"))
+}
+
+func TestRBACClient_HotReload(t *testing.T) {
+	c, path := newTestRBACClient(t, testPolicy, time.Minute)
+	ctx := context.Background()
+
+	d, err := c.CheckPermission(ctx, "bob", "/adhoc.v1.AdhocService/Hello", "call")
+	require.NoError(t, err)
+	require.False(t, d.Allowed)
+
+	// 无效策略（引用未定义角色）被拒绝，旧策略继续生效
+	require.NoError(t, os.WriteFile(path, []byte(testPolicy+"  - subject: carol\n    roles: [ghost]\n"), 0o600))
+	time.Sleep(200 * time.Millisecond)
+	d, err = c.CheckPermission(ctx, "Alice", "anything", "delete")
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+
+	// 新策略生效后缓存失效
+	require.NoError(t, os.WriteFile(path, []byte(testPolicy+"  - subject: bob\n    roles: [admin]\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		d, err := c.CheckPermission(ctx, "bob", "/adhoc.v1.AdhocService/Hello", "call")
+		return err == nil && d.Allowed
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestNewRBACClient_InvalidPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yml")
+	require.NoError(t, os.WriteFile(path, []byte("bindings:\n  - subject: bob\n    roles: [ghost]\n"), 0o600))
+	_, err := NewRBACClient(config.RBACConfig{PolicyFile: path})
+	assert.ErrorContains(t, err, `undefined role "ghost"`)
+}
+
+func TestWildcardMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"*", "", true},
+		{"api", "api", true},
+		{"api", "apis", false},
+		{"/adhoc.v1.*/List*", "/adhoc.v1.AdhocService/ListAccessLogs", true},
+		{"/adhoc.v1.*/List*", "/adhoc.v1.AdhocService/Hello", false},
+		{"a*b*c", "abc", true},
+		{"a*b*c", "acb", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, wildcardMatch(tt.pattern, tt.s), "%q ~ %q", tt.pattern, tt.s)
+	}
+}
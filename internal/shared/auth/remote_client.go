@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authv1 "youlingserv/gen/go/auth/v1"
+	"youlingserv/pkg/breaker"
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/grpcclient"
+	"youlingserv/pkg/log"
+)
+
+const (
+	defaultRemoteTimeout      = 500 * time.Millisecond
+	defaultRemoteRetryBackoff = 50 * time.Millisecond
+)
+
+// ErrAuthUnavailable 权限服务不可用（超时、熔断等）且配置为失败时拒绝
+var ErrAuthUnavailable = errors.New("permission service unavailable")
+
+// RemoteClient 调用外部权限服务 auth.v1.PermissionService 的 AuthClient
+// 超时与 Unavailable 按指数退避重试，连续失败触发熔断；允许 / 拒绝结果分别按各自的 TTL 缓存
+// 权限服务不可用时按 fail_open 放行或返回 ErrAuthUnavailable
+type RemoteClient struct {
+	client   authv1.PermissionServiceClient
+	timeout  time.Duration
+	retries  int
+	backoff  time.Duration
+	failOpen bool
+	breaker  *breaker.Breaker
+
+	allowCache *decisionCache
+	denyCache  *decisionCache
+}
+
+var _ AuthClient = (*RemoteClient)(nil)
+
+// NewRemoteClient 创建连接到 conf.Target 的客户端，cleanup 关闭连接
+func NewRemoteClient(conf config.RemoteAuthConfig) (*RemoteClient, func(), error) {
+	pool, err := grpcclient.NewPool(conf.Target, 1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("auth: failed to create permission service client: %w", err)
+	}
+	cleanup := func() {
+		if err := pool.Close(); err != nil {
+			log.GetLogger().Error("Failed to close permission service client", zap.Error(err))
+		}
+	}
+	return newRemoteClient(authv1.NewPermissionServiceClient(pool), conf), cleanup, nil
+}
+
+func newRemoteClient(client authv1.PermissionServiceClient, conf config.RemoteAuthConfig) *RemoteClient {
+	c := &RemoteClient{
+		client:     client,
+		timeout:    conf.Timeout,
+		retries:    conf.Retries,
+		backoff:    conf.RetryBackoff,
+		failOpen:   conf.FailOpen,
+		allowCache: newDecisionCache(conf.AllowCacheTTL, conf.CacheSize),
+		denyCache:  newDecisionCache(conf.DenyCacheTTL, conf.CacheSize),
+	}
+	if c.timeout <= 0 {
+		c.timeout = defaultRemoteTimeout
+	}
+	if c.retries < 0 {
+		c.retries = 0
+	}
+	if c.backoff <= 0 {
+		c.backoff = defaultRemoteRetryBackoff
+	}
+	c.breaker = breaker.New(conf.BreakerThreshold, conf.BreakerTimeout, func(from, to breaker.State) {
+		log.GetLogger().Warn("Permission service circuit breaker state changed", zap.Stringer("from", from), zap.Stringer("to", to))
+	})
+	return c
+}
+
+// CheckPermission ctx 中的 Principal 与 userID 一致时，其角色与租户一并发送给权限服务
+func (c *RemoteClient) CheckPermission(ctx context.Context, userID, resource, action string) (Decision, error) {
+	req := &authv1.CheckRequest{Subject: userID, Resource: resource, Action: action}
+	if p, ok := PrincipalFromContext(ctx); ok && p.Subject == userID {
+		req.Roles = p.Roles
+		req.Tenant = p.Tenant
+	}
+
+	key := strings.Join([]string{req.Subject, strings.Join(req.Roles, ","), req.Tenant, req.Resource, req.Action}, "\x00")
+	if d, ok := c.allowCache.get(key); ok {
+		return d, nil
+	}
+	if d, ok := c.denyCache.get(key); ok {
+		return d, nil
+	}
+
+	resp, err := c.check(ctx, req)
+	if err != nil {
+		// 调用方自身取消时不算权限服务故障
+		if ctx.Err() != nil {
+			return Decision{}, ctx.Err()
+		}
+		if c.failOpen {
+			log.FromContext(ctx).Warn("Permission service unavailable, failing open",
+				zap.String("subject", userID), zap.String("resource", resource), zap.String("action", action), zap.Error(err))
+			return Decision{Allowed: true, Reason: "permission service unavailable, failing open"}, nil
+		}
+		return Decision{}, fmt.Errorf("%w: %v", ErrAuthUnavailable, err)
+	}
+
+	d := Decision{Allowed: resp.GetAllowed(), Reason: resp.GetReason()}
+	if d.Allowed {
+		c.allowCache.put(key, d)
+	} else {
+		c.denyCache.put(key, d)
+	}
+	return d, nil
+}
+
+// check 带重试与熔断地调用权限服务
+func (c *RemoteClient) check(ctx context.Context, req *authv1.CheckRequest) (*authv1.CheckResponse, error) {
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		if err := c.breaker.Allow(); err != nil {
+			return nil, err
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		resp, err := c.client.Check(callCtx, req)
+		cancel()
+
+		retryable := isRetryable(err)
+		// 只有超时和不可用说明权限服务故障，参数错误等不计入熔断
+		c.breaker.Done(!retryable)
+		if err == nil || !retryable || attempt >= c.retries || ctx.Err() != nil {
+			return resp, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
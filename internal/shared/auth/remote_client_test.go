@@ -0,0 +1,135 @@
+package auth_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"youlingserv/internal/shared/auth"
+	"youlingserv/internal/shared/auth/authtest"
+	"youlingserv/pkg/config"
+)
+
+const remotePolicy = `
+roles:
+  - name: reader
+    grants:
+      - resource: adhoc/access-logs
+        actions: [list]
+bindings:
+  - subject: alice
+    roles: [reader]
+`
+
+func startFakeServer(t *testing.T) (*authtest.FakePermissionServer, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.yml")
+	require.NoError(t, os.WriteFile(path, []byte(remotePolicy), 0o600))
+	rbac, err := auth.NewRBACClient(config.RBACConfig{PolicyFile: path})
+	require.NoError(t, err)
+
+	fake := authtest.NewFakePermissionServer(rbac)
+	addr, stop, err := authtest.Serve("127.0.0.1:0", fake)
+	require.NoError(t, err)
+	t.Cleanup(stop)
+	return fake, addr
+}
+
+func newRemoteClient(t *testing.T, conf config.RemoteAuthConfig) auth.AuthClient {
+	t.Helper()
+	client, cleanup, err := auth.NewRemoteClient(conf)
+	require.NoError(t, err)
+	t.Cleanup(cleanup)
+	return client
+}
+
+func TestRemoteClient_DecisionsAndCache(t *testing.T) {
+	fake, addr := startFakeServer(t)
+	client := newRemoteClient(t, config.RemoteAuthConfig{
+		Target:        addr,
+		Timeout:       time.Second,
+		AllowCacheTTL: time.Minute,
+		DenyCacheTTL:  time.Minute,
+	})
+	ctx := context.Background()
+
+	d, err := client.CheckPermission(ctx, "alice", "adhoc/access-logs", "list")
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+
+	d, err = client.CheckPermission(ctx, "bob", "adhoc/access-logs", "list")
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+	assert.Contains(t, d.Reason, "no roles bound")
+
+	// 角色随请求发送给权限服务
+	withRoles := auth.WithPrincipal(ctx, &auth.Principal{Subject: "bob", Roles: []string{"reader"}})
+	d, err = client.CheckPermission(withRoles, "bob", "adhoc/access-logs", "list")
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+
+	// 允许与拒绝的结果都命中缓存
+	calls := fake.Calls()
+	_, _ = client.CheckPermission(ctx, "alice", "adhoc/access-logs", "list")
+	_, _ = client.CheckPermission(ctx, "bob", "adhoc/access-logs", "list")
+	assert.Equal(t, calls, fake.Calls())
+}
+
+func TestRemoteClient_Retries(t *testing.T) {
+	fake, addr := startFakeServer(t)
+	client := newRemoteClient(t, config.RemoteAuthConfig{
+		Target:       addr,
+		Timeout:      100 * time.Millisecond,
+		Retries:      2,
+		RetryBackoff: time.Millisecond,
+	})
+
+	fake.FailNext(2, codes.Unavailable)
+	d, err := client.CheckPermission(context.Background(), "alice", "adhoc/access-logs", "list")
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+	assert.EqualValues(t, 3, fake.Calls())
+
+	// 超时同样重试，重试用尽后失败时拒绝
+	fake.SetDelay(time.Second)
+	_, err = client.CheckPermission(context.Background(), "alice", "adhoc/access-logs", "list")
+	assert.ErrorIs(t, err, auth.ErrAuthUnavailable)
+	assert.EqualValues(t, 6, fake.Calls())
+}
+
+func TestRemoteClient_CircuitBreakerAndFailMode(t *testing.T) {
+	fake, addr := startFakeServer(t)
+	conf := config.RemoteAuthConfig{
+		Target:           addr,
+		Timeout:          time.Second,
+		BreakerThreshold: 2,
+		BreakerTimeout:   time.Hour,
+	}
+	ctx := context.Background()
+
+	closed := newRemoteClient(t, conf)
+	fake.FailNext(100, codes.Unavailable)
+	for i := 0; i < 2; i++ {
+		_, err := closed.CheckPermission(ctx, "alice", "adhoc/access-logs", "list")
+		assert.ErrorIs(t, err, auth.ErrAuthUnavailable)
+	}
+	// 熔断后不再访问权限服务
+	calls := fake.Calls()
+	_, err := closed.CheckPermission(ctx, "alice", "adhoc/access-logs", "list")
+	assert.ErrorIs(t, err, auth.ErrAuthUnavailable)
+	assert.Equal(t, calls, fake.Calls())
+
+	conf.FailOpen = true
+	open := newRemoteClient(t, conf)
+	for i := 0; i < 3; i++ {
+		d, err := open.CheckPermission(ctx, "bob", "adhoc/access-logs", "list")
+		require.NoError(t, err)
+		assert.True(t, d.Allowed, "fail-open must allow while the service is down")
+	}
+}
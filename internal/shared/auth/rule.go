@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	commonpb "youlingserv/gen/go/common"
+	"youlingserv/pkg/config"
+)
+
+// Rule 一个端点的授权规则
+type Rule struct {
+	Resource string
+	Action   string
+	Public   bool // 公开端点，跳过认证与鉴权
+}
+
+func (r Rule) validate() error {
+	if !r.Public && (r.Resource == "" || r.Action == "") {
+		return errors.New("resource and action are required unless public")
+	}
+	return nil
+}
+
+// MethodRule 读取 gRPC 方法上的 (common.auth) 选项，fullMethod 形如 /adhoc.v1.AdhocService/Hello
+func MethodRule(fullMethod string) (Rule, bool) {
+	name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(fullMethod, "/"), "/", "."))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return Rule{}, false
+	}
+	md, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok || !proto.HasExtension(md.Options(), commonpb.E_Auth) {
+		return Rule{}, false
+	}
+	opt := proto.GetExtension(md.Options(), commonpb.E_Auth).(*commonpb.AuthRule)
+	rule := Rule{Resource: opt.GetResource(), Action: opt.GetAction(), Public: opt.GetPublic()}
+	return rule, rule.validate() == nil
+}
+
+// MethodRules 按 gRPC 全方法名解析授权规则
+// 优先使用方法上的 (common.auth) 选项，其次是配置中的 grpc_methods（用于反射等无法修改 proto 的服务）
+type MethodRules struct {
+	overrides []config.GRPCMethodRule
+	cache     sync.Map // fullMethod → lookupResult
+}
+
+type lookupResult struct {
+	rule Rule
+	ok   bool
+}
+
+// NewMethodRules 校验配置中的规则
+func NewMethodRules(conf config.AuthConfig) (*MethodRules, error) {
+	for i, m := range conf.GRPCMethods {
+		rule := Rule{Resource: m.Resource, Action: m.Action, Public: m.Public}
+		if m.Method == "" {
+			return nil, fmt.Errorf("auth: grpc_methods[%d]: missing method", i)
+		}
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("auth: grpc_methods[%d] %s: %w", i, m.Method, err)
+		}
+	}
+	return &MethodRules{overrides: conf.GRPCMethods}, nil
+}
+
+// Lookup 返回方法的授权规则，未声明时返回 false
+func (r *MethodRules) Lookup(fullMethod string) (Rule, bool) {
+	if v, ok := r.cache.Load(fullMethod); ok {
+		res := v.(lookupResult)
+		return res.rule, res.ok
+	}
+
+	rule, ok := MethodRule(fullMethod)
+	if !ok {
+		for _, m := range r.overrides {
+			if wildcardMatch(m.Method, fullMethod) {
+				rule, ok = Rule{Resource: m.Resource, Action: m.Action, Public: m.Public}, true
+				break
+			}
+		}
+	}
+	// 拦截器只会收到已注册的方法，缓存不会无限增长
+	r.cache.Store(fullMethod, lookupResult{rule: rule, ok: ok})
+	return rule, ok
+}
+
+// RouteRules HTTP 路由（方法 + 注册时的路由模式）到授权规则的映射
+type RouteRules struct {
+	mu    sync.RWMutex
+	rules map[string]Rule
+}
+
+// NewRouteRules 加载配置中的 http_routes
+func NewRouteRules(conf config.AuthConfig) (*RouteRules, error) {
+	r := &RouteRules{rules: make(map[string]Rule, len(conf.HTTPRoutes))}
+	for i, route := range conf.HTTPRoutes {
+		if route.Method == "" || route.Path == "" {
+			return nil, fmt.Errorf("auth: http_routes[%d]: method and path are required", i)
+		}
+		if err := r.Set(route.Method, route.Path, Rule{Resource: route.Resource, Action: route.Action, Public: route.Public}); err != nil {
+			return nil, fmt.Errorf("auth: http_routes[%d] %s %s: %w", i, route.Method, route.Path, err)
+		}
+	}
+	return r, nil
+}
+
+// Set 声明路由的授权规则，网关转码路由注册时用对应 RPC 的规则调用
+func (r *RouteRules) Set(method, path string, rule Rule) error {
+	if err := rule.validate(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules[routeKey(method, path)] = rule
+	return nil
+}
+
+// Lookup path 为注册路由时的模式（Hertz 的 FullPath），未声明时返回 false
+func (r *RouteRules) Lookup(method, path string) (Rule, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rule, ok := r.rules[routeKey(method, path)]
+	return rule, ok
+}
+
+func routeKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_ "youlingserv/gen/go/adhoc/v1" // 注册带 (common.auth) 选项的方法描述符
+	"youlingserv/pkg/config"
+)
+
+func TestMethodRules(t *testing.T) {
+	rules, err := NewMethodRules(config.AuthConfig{GRPCMethods: []config.GRPCMethodRule{
+		{Method: "/grpc.reflection.v1.ServerReflection/*", Public: true},
+		// proto 选项优先于配置
+		{Method: "/adhoc.v1.AdhocService/*", Public: true},
+	}})
+	require.NoError(t, err)
+
+	rule, ok := rules.Lookup("/adhoc.v1.AdhocService/Goodbye")
+	require.True(t, ok)
+	assert.Equal(t, Rule{Resource: "adhoc/greetings", Action: "goodbye"}, rule)
+
+	rule, ok = rules.Lookup("/adhoc.v1.AdhocService/WatchAccessLogs")
+	require.True(t, ok)
+	assert.Equal(t, Rule{Resource: "adhoc/access-logs", Action: "watch"}, rule)
+
+	rule, ok = rules.Lookup("/grpc.reflection.v1.ServerReflection/ServerReflectionInfo")
+	require.True(t, ok)
+	assert.True(t, rule.Public)
+
+	_, ok = rules.Lookup("/grpc.health.v1.Health/Check")
+	assert.False(t, ok, "unmapped methods must not resolve")
+
+	_, err = NewMethodRules(config.AuthConfig{GRPCMethods: []config.GRPCMethodRule{{Method: "/a.B/C", Resource: "r"}}})
+	assert.Error(t, err, "non-public rule without action")
+}
+
+func TestRouteRules(t *testing.T) {
+	rules, err := NewRouteRules(config.AuthConfig{HTTPRoutes: []config.HTTPRouteRule{
+		{Method: "post", Path: "/api/v1/hello", Resource: "api/greetings", Action: "hello"},
+		{Method: "GET", Path: "/healthz", Public: true},
+	}})
+	require.NoError(t, err)
+
+	rule, ok := rules.Lookup("POST", "/api/v1/hello")
+	require.True(t, ok)
+	assert.Equal(t, Rule{Resource: "api/greetings", Action: "hello"}, rule)
+
+	rule, ok = rules.Lookup("GET", "/healthz")
+	require.True(t, ok)
+	assert.True(t, rule.Public)
+
+	_, ok = rules.Lookup("GET", "/api/v1/hello")
+	assert.False(t, ok, "rules are per method")
+
+	_, err = NewRouteRules(config.AuthConfig{HTTPRoutes: []config.HTTPRouteRule{{Method: "GET", Path: "/x"}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"youlingserv/pkg/config"
+)
+
+const (
+	defaultRolesClaim  = "roles"
+	defaultTenantClaim = "tenant"
+)
+
+var defaultAlgorithms = []string{"HS256", "RS256", "EdDSA"}
+
+// TokenVerifier 校验 JWT 签名与 exp / nbf / iss / aud，并提取调用方
+// HS* 使用 secret 或 JWKS 中的 oct 密钥，RS* / EdDSA 使用 JWKS 公钥
+type TokenVerifier struct {
+	parser      *jwt.Parser
+	secret      []byte
+	keys        *KeySet
+	rolesClaim  string
+	tenantClaim string
+}
+
+// NewTokenVerifier 创建校验器，配置了 JWKS 时返回的 cleanup 停止后台刷新
+func NewTokenVerifier(conf config.JWTConfig) (*TokenVerifier, func(), error) {
+	algorithms := conf.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = defaultAlgorithms
+	}
+	for _, alg := range algorithms {
+		if jwt.GetSigningMethod(alg) == nil || alg == jwt.SigningMethodNone.Alg() {
+			return nil, nil, fmt.Errorf("jwt: unsupported algorithm %q", alg)
+		}
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(algorithms),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(conf.Leeway),
+	}
+	if conf.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(conf.Issuer))
+	}
+	if conf.Audience != "" {
+		opts = append(opts, jwt.WithAudience(conf.Audience))
+	}
+
+	v := &TokenVerifier{
+		parser:      jwt.NewParser(opts...),
+		secret:      []byte(conf.Secret),
+		rolesClaim:  conf.RolesClaim,
+		tenantClaim: conf.TenantClaim,
+	}
+	if v.rolesClaim == "" {
+		v.rolesClaim = defaultRolesClaim
+	}
+	if v.tenantClaim == "" {
+		v.tenantClaim = defaultTenantClaim
+	}
+
+	cleanup := func() {}
+	if conf.JWKSFile != "" || conf.JWKSURL != "" {
+		keys, err := NewKeySet(conf.JWKSFile, conf.JWKSURL, conf.JWKSRefreshInterval)
+		if err != nil {
+			return nil, nil, err
+		}
+		v.keys = keys
+		cleanup = keys.Close
+	} else if conf.Secret == "" {
+		return nil, nil, errors.New("jwt: neither secret nor jwks_file / jwks_url is configured")
+	}
+	return v, cleanup, nil
+}
+
+// Verify 校验 token 并返回调用方，token 不含 Bearer 前缀
+func (v *TokenVerifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		return v.key(ctx, t)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := claims.GetSubject()
+	if err != nil {
+		return nil, err
+	}
+	if sub == "" {
+		return nil, errors.New("token has no subject")
+	}
+	tenant, _ := claims[v.tenantClaim].(string)
+	return &Principal{
+		Subject: sub,
+		Roles:   stringList(claims[v.rolesClaim]),
+		Tenant:  tenant,
+	}, nil
+}
+
+// key 按 header 中的 alg / kid 选择校验密钥
+func (v *TokenVerifier) key(ctx context.Context, t *jwt.Token) (interface{}, error) {
+	alg := t.Method.Alg()
+	kid, _ := t.Header["kid"].(string)
+
+	if strings.HasPrefix(alg, "HS") && len(v.secret) > 0 && (kid == "" || v.keys == nil) {
+		return v.secret, nil
+	}
+	if v.keys == nil {
+		return nil, errKeyNotFound
+	}
+	return v.keys.Key(ctx, kid, alg)
+}
+
+// stringList 角色 claim 可以是字符串数组，也可以是空格分隔的字符串（如 OAuth scope）
+func stringList(v interface{}) []string {
+	switch val := v.(type) {
+	case string:
+		return strings.Fields(val)
+	case []interface{}:
+		out := make([]string, 0, len(val))
+		for _, item := range val {
+			if s, ok := item.(string); ok && s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"youlingserv/pkg/config"
+)
+
+const testSecret = "test-secret-at-least-32-bytes-long!!"
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":    "user123",
+		"iss":    "https://idp.example.com",
+		"aud":    "youlingserv",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"roles":  []string{"admin", "viewer"},
+		"tenant": "acme",
+	}
+}
+
+func rsaJWK(kid string, pub *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA", "kid": kid, "use": "sig", "alg": "RS256",
+		"n": base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}
+}
+
+func ed25519JWK(kid string, pub ed25519.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "OKP", "crv": "Ed25519", "kid": kid,
+		"x": base64.RawURLEncoding.EncodeToString(pub),
+	}
+}
+
+func writeJWKS(t *testing.T, path string, keys ...map[string]string) {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{"keys": keys})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func TestTokenVerifier_HS256Claims(t *testing.T) {
+	v, cleanup, err := NewTokenVerifier(config.JWTConfig{
+		Secret:   testSecret,
+		Issuer:   "https://idp.example.com",
+		Audience: "youlingserv",
+	})
+	require.NoError(t, err)
+	defer cleanup()
+	ctx := context.Background()
+
+	p, err := v.Verify(ctx, sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, &Principal{Subject: "user123", Roles: []string{"admin", "viewer"}, Tenant: "acme"}, p)
+
+	tests := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+		key    []byte
+		want   error
+	}{
+		{"expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, nil, jwt.ErrTokenExpired},
+		{"missing exp", func(c jwt.MapClaims) { delete(c, "exp") }, nil, jwt.ErrTokenRequiredClaimMissing},
+		{"not yet valid", func(c jwt.MapClaims) { c["nbf"] = time.Now().Add(time.Hour).Unix() }, nil, jwt.ErrTokenNotValidYet},
+		{"wrong audience", func(c jwt.MapClaims) { c["aud"] = "other" }, nil, jwt.ErrTokenInvalidAudience},
+		{"wrong issuer", func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }, nil, jwt.ErrTokenInvalidIssuer},
+		{"wrong secret", func(jwt.MapClaims) {}, []byte("another-secret-at-least-32-bytes!!"), jwt.ErrTokenSignatureInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			tt.mutate(claims)
+			key := tt.key
+			if key == nil {
+				key = []byte(testSecret)
+			}
+			_, err := v.Verify(ctx, sign(t, jwt.SigningMethodHS256, key, "", claims))
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestTokenVerifier_RejectsDisallowedAlgorithm(t *testing.T) {
+	v, cleanup, err := NewTokenVerifier(config.JWTConfig{Secret: testSecret, Algorithms: []string{"RS256"}})
+	require.NoError(t, err)
+	defer cleanup()
+
+	_, err = v.Verify(context.Background(), sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", validClaims()))
+	assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+}
+
+func TestTokenVerifier_JWKSFileRotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, rsaJWK("old", &oldKey.PublicKey))
+
+	v, cleanup, err := NewTokenVerifier(config.JWTConfig{JWKSFile: path})
+	require.NoError(t, err)
+	defer cleanup()
+	ctx := context.Background()
+
+	_, err = v.Verify(ctx, sign(t, jwt.SigningMethodRS256, oldKey, "old", validClaims()))
+	require.NoError(t, err)
+
+	// 新 kid 出现时按需刷新；刚加载过时受最小刷新间隔限制
+	writeJWKS(t, path, rsaJWK("new", &newKey.PublicKey))
+	newToken := sign(t, jwt.SigningMethodRS256, newKey, "new", validClaims())
+	_, err = v.Verify(ctx, newToken)
+	assert.True(t, errors.Is(err, errKeyNotFound), "refresh must be rate limited, got %v", err)
+
+	v.keys.mu.Lock()
+	v.keys.lastRefresh = time.Now().Add(-jwksMinRefreshInterval)
+	v.keys.mu.Unlock()
+	_, err = v.Verify(ctx, newToken)
+	require.NoError(t, err)
+
+	_, err = v.Verify(ctx, sign(t, jwt.SigningMethodRS256, oldKey, "old", validClaims()))
+	assert.ErrorIs(t, err, errKeyNotFound, "rotated-out key must no longer verify")
+}
+
+func TestTokenVerifier_JWKSURLEdDSA(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{ed25519JWK("ed-1", pub), {"kty": "EC", "kid": "unsupported"}},
+		})
+	}))
+	defer srv.Close()
+
+	v, cleanup, err := NewTokenVerifier(config.JWTConfig{JWKSURL: srv.URL})
+	require.NoError(t, err)
+	defer cleanup()
+
+	p, err := v.Verify(context.Background(), sign(t, jwt.SigningMethodEdDSA, priv, "ed-1", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, "user123", p.Subject)
+}
+
+func TestNewTokenVerifier_Config(t *testing.T) {
+	_, _, err := NewTokenVerifier(config.JWTConfig{})
+	assert.Error(t, err, "no key source")
+
+	_, _, err = NewTokenVerifier(config.JWTConfig{Secret: testSecret, Algorithms: []string{"none"}})
+	assert.Error(t, err)
+
+	_, _, err = NewTokenVerifier(config.JWTConfig{JWKSFile: filepath.Join(t.TempDir(), "missing.json")})
+	assert.Error(t, err, "initial JWKS load failure must fail startup")
+}
+
+func TestAuthenticator(t *testing.T) {
+	ctx := context.Background()
+
+	jwtAuth, cleanup, err := NewAuthenticator(config.AuthConfig{Mode: ModeJWT, JWT: config.JWTConfig{Secret: testSecret}}, nil)
+	require.NoError(t, err)
+	defer cleanup()
+
+	_, err = jwtAuth.Authenticate(ctx, Credentials{UserID: "user123"})
+	assert.ErrorIs(t, err, ErrMissingCredentials, "jwt mode must ignore X-User-ID")
+
+	_, err = jwtAuth.Authenticate(ctx, Credentials{Authorization: "Bearer not-a-jwt"})
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	token := sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", validClaims())
+	p, err := jwtAuth.Authenticate(ctx, Credentials{Authorization: "bearer " + token})
+	require.NoError(t, err)
+	assert.Equal(t, "user123", p.Subject)
+
+	headerAuth, _, err := NewAuthenticator(config.AuthConfig{Mode: ModeHeader}, nil)
+	require.NoError(t, err)
+	p, err = headerAuth.Authenticate(ctx, Credentials{UserID: "user123"})
+	require.NoError(t, err)
+	assert.Equal(t, "user123", p.Subject)
+
+	_, _, err = NewAuthenticator(config.AuthConfig{Mode: "basic"}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,116 @@
+// Package errcode 带业务错误码的错误类型，以及到 gRPC status 的转换
+package errcode
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"youlingserv/gen/go/common"
+)
+
+// Error 业务错误，Msg 面向调用方，Err 为内部原因，不会返回给调用方
+type Error struct {
+	Code common.ErrorCode
+	Msg  string
+	Err  error
+}
+
+// New 创建业务错误
+func New(code common.ErrorCode, msg string) *Error {
+	return &Error{Code: code, Msg: msg}
+}
+
+// Wrap 用业务错误码包装内部错误
+func Wrap(code common.ErrorCode, err error, msg string) *Error {
+	return &Error{Code: code, Msg: msg, Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Msg + ": " + e.Err.Error()
+	}
+	return e.Msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus 实现 status.FromError 识别的接口，details 中附带 common.ErrorDetail
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(GRPCCode(e.Code), e.Msg)
+	if withDetails, err := st.WithDetails(&common.ErrorDetail{Code: e.Code, Message: e.Msg}); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// GRPCCode 业务错误码 → gRPC 状态码
+func GRPCCode(code common.ErrorCode) codes.Code {
+	switch code {
+	case common.ErrorCode_INVALID_ARGUMENT:
+		return codes.InvalidArgument
+	case common.ErrorCode_NOT_FOUND:
+		return codes.NotFound
+	case common.ErrorCode_ALREADY_EXISTS:
+		return codes.AlreadyExists
+	case common.ErrorCode_PERMISSION_DENIED:
+		return codes.PermissionDenied
+	case common.ErrorCode_UNAUTHENTICATED:
+		return codes.Unauthenticated
+	case common.ErrorCode_INTERNAL_ERROR:
+		return codes.Internal
+	case common.ErrorCode_RESOURCE_EXHAUSTED:
+		return codes.ResourceExhausted
+	case common.ErrorCode_UNAVAILABLE:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
+
+// ToStatus 把 biz 层返回的错误转换为 gRPC status 错误
+// 未携带业务错误码的错误按常见类型归类，其余一律视为内部错误，不向调用方暴露细节
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.GRPCStatus().Err()
+	}
+	// 下游 gRPC 调用返回的 status 原样透传
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request canceled")
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		e = New(common.ErrorCode_NOT_FOUND, "record not found")
+	default:
+		e = New(common.ErrorCode_INTERNAL_ERROR, "internal error")
+	}
+	return e.GRPCStatus().Err()
+}
+
+// CodeOf 从 gRPC 错误的 details 中取出业务错误码，没有时返回 UNKNOWN
+func CodeOf(err error) common.ErrorCode {
+	st, ok := status.FromError(err)
+	if !ok {
+		return common.ErrorCode_UNKNOWN
+	}
+	for _, d := range st.Details() {
+		if detail, ok := d.(*common.ErrorDetail); ok {
+			return detail.GetCode()
+		}
+	}
+	return common.ErrorCode_UNKNOWN
+}
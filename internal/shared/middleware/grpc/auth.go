@@ -2,34 +2,97 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	"youlingserv/internal/shared/auth"
+	"youlingserv/pkg/log"
 )
 
-func AuthInterceptor(checker *auth.PermissionChecker) grpc.UnaryServerInterceptor {
+// AuthInterceptor 按方法的授权规则认证并鉴权，未声明规则的方法一律拒绝，公开方法直接放行
+func AuthInterceptor(authenticator auth.Authenticator, checker *auth.PermissionChecker, rules *auth.MethodRules) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "missing metadata")
-		}
-
-		userIDs := md.Get("user-id")
-		if len(userIDs) == 0 {
-			return nil, status.Error(codes.Unauthenticated, "missing user ID")
+		ctx, err := authorize(ctx, info.FullMethod, authenticator, checker, rules)
+		if err != nil {
+			return nil, err
 		}
-		userID := userIDs[0]
+		return handler(ctx, req)
+	}
+}
 
-		err := checker.CheckAccess(ctx, userID, "grpc", "call")
+// StreamAuthInterceptor 流式 RPC 的鉴权，与 AuthInterceptor 规则一致
+func StreamAuthInterceptor(authenticator auth.Authenticator, checker *auth.PermissionChecker, rules *auth.MethodRules) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), info.FullMethod, authenticator, checker, rules)
 		if err != nil {
-			return nil, status.Error(codes.PermissionDenied, err.Error())
+			return err
 		}
+		return handler(srv, &wrappedServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
 
-		ctx = context.WithValue(ctx, "userID", userID)
-		return handler(ctx, req)
+// authorize 查找方法的授权规则，从 metadata 读取凭证识别调用方并校验权限，返回携带 Principal 的 ctx
+func authorize(ctx context.Context, fullMethod string, authenticator auth.Authenticator, checker *auth.PermissionChecker, rules *auth.MethodRules) (context.Context, error) {
+	rule, ok := rules.Lookup(fullMethod)
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "no authorization rule for %s", fullMethod)
+	}
+	if rule.Public {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	principal, err := authenticator.Authenticate(ctx, auth.Credentials{
+		Authorization: firstValue(md, "authorization"),
+		UserID:        firstValue(md, "user-id"),
+		Peer:          peerIdentity(ctx),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	ctx = auth.WithPrincipal(ctx, principal)
+	ctx = log.WithFields(ctx, zap.String(log.FieldUserID, principal.Subject))
+	err = checker.CheckAccess(ctx, principal.Subject, rule.Resource, rule.Action)
+	if errors.Is(err, auth.ErrPermissionDenied) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, auth.ErrAuthUnavailable) {
+		log.FromContext(ctx).Error("Permission check failed", zap.Error(err))
+		return nil, status.Error(codes.Unavailable, "permission service unavailable")
+	}
+	if err != nil {
+		log.FromContext(ctx).Error("Permission check failed", zap.Error(err))
+		return nil, status.Error(codes.Internal, "permission check failed")
+	}
+
+	return ctx, nil
+}
+
+// peerIdentity 取 mTLS 客户端证书标识的服务，未使用 TLS 或客户端未出示证书时为空
+// 只有服务端配置了 client_auth 时才会请求客户端证书，此时证书已按 CA 校验
+func peerIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return ""
+	}
+	return auth.CertificateIdentity(info.State.PeerCertificates[0])
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
 	}
+	return ""
 }
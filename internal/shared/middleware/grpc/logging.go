@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"youlingserv/pkg/log"
+)
+
+// LogContextInterceptor 把调用的方法名写入 ctx，后续 log.FromContext 输出的日志都带 rpc_method 字段
+// 需放在 TracingInterceptor 之后、RecoveryInterceptor 之前，panic 日志也能带上方法名与 trace_id
+func LogContextInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(log.WithFields(ctx, zap.String(log.FieldRPCMethod, info.FullMethod)), req)
+	}
+}
+
+// StreamLogContextInterceptor 流式 RPC 的日志字段，与 LogContextInterceptor 一致
+func StreamLogContextInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := log.WithFields(ss.Context(), zap.String(log.FieldRPCMethod, info.FullMethod))
+		return handler(srv, &wrappedServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
@@ -9,12 +9,31 @@ import (
 	"youlingserv/pkg/observability"
 )
 
+// MetricsInterceptor 一元 RPC 的调用数、耗时与处理中的调用数
 func MetricsInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		done := observability.GRPCCallStarted(info.FullMethod)
+		defer done()
+
 		start := time.Now()
 		resp, err := handler(ctx, req)
 		duration := time.Since(start)
-		observability.RecordGRPCMetrics(info.FullMethod, err, duration)
+		observability.RecordGRPCMetrics(ctx, info.FullMethod, err, duration)
 		return resp, err
 	}
 }
+
+// StreamMetricsInterceptor 流式 RPC 的指标，除耗时外记录该流收发的消息数
+func StreamMetricsInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		done := observability.GRPCStreamStarted(info.FullMethod)
+		defer done()
+
+		start := time.Now()
+		counted := &countingServerStream{ServerStream: ss}
+		err := handler(srv, counted)
+		duration := time.Since(start)
+		observability.RecordGRPCStreamMetrics(ss.Context(), info.FullMethod, err, duration, counted.sent, counted.received)
+		return err
+	}
+}
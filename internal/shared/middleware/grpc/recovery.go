@@ -2,8 +2,8 @@ package grpc
 
 import (
 	"context"
-	"fmt"
 
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -15,7 +15,7 @@ func RecoveryInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		defer func() {
 			if r := recover(); r != nil {
-				log.GetLogger().Error(fmt.Sprintf("panic recovered: %v", r))
+				log.FromContext(ctx).Error("panic recovered", zap.Any("panic", r))
 				err = status.Errorf(codes.Internal, "internal server error: %v", r)
 			}
 		}()
@@ -23,3 +23,17 @@ func RecoveryInterceptor() grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
+
+// StreamRecoveryInterceptor 流式 RPC 的 panic 恢复
+func StreamRecoveryInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.FromContext(ss.Context()).Error("panic recovered in stream", zap.Any("panic", r))
+				err = status.Errorf(codes.Internal, "internal server error: %v", r)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"youlingserv/pkg/log"
+	"youlingserv/pkg/requestid"
+)
+
+// RequestIDInterceptor 沿用 metadata 中的 x-request-id（不合法时重新生成 ULID），写入 ctx 并通过响应 header 返回
+// 放在拦截器链最前面，被后续拦截器拒绝的调用也带有请求 ID
+func RequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id := incomingRequestID(ctx)
+		ctx = requestid.NewContext(ctx, id)
+		if err := grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id)); err != nil {
+			log.FromContext(ctx).Debug("Failed to set request id header")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRequestIDInterceptor 流式 RPC 的请求 ID，与 RequestIDInterceptor 一致
+func StreamRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := incomingRequestID(ss.Context())
+		ctx := requestid.NewContext(ss.Context(), id)
+		if err := ss.SetHeader(metadata.Pairs(requestid.MetadataKey, id)); err != nil {
+			log.FromContext(ctx).Debug("Failed to set request id header")
+		}
+		return handler(srv, &wrappedServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// RequestIDClientInterceptor 把 ctx 中的请求 ID 通过 x-request-id metadata 传给下游，下游日志与上游使用同一个 ID
+func RequestIDClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if id := requestid.FromContext(ctx); id != "" {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			md = md.Copy()
+			md.Set(requestid.MetadataKey, id)
+			ctx = metadata.NewOutgoingContext(ctx, md)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func incomingRequestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return requestid.Ensure(firstValue(md, requestid.MetadataKey))
+}
@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"youlingserv/pkg/requestid"
+)
+
+func TestRequestID_ForwardedToDownstream(t *testing.T) {
+	const method = "/adhoc.v1.AdhocService/Hello"
+	server := RequestIDInterceptor()
+	var seen string
+
+	// 网关的出站调用把 ctx 中的 ID 写入 metadata，adhoc-server 沿用该 ID
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		_, err := server(metadata.NewIncomingContext(context.Background(), md), nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				seen = requestid.FromContext(ctx)
+				return nil, nil
+			})
+		return err
+	}
+	ctx := requestid.NewContext(context.Background(), "01J9ZC7W8M5Q3T6V2XK4N0R1BD")
+	assert.NoError(t, RequestIDClientInterceptor()(ctx, method, nil, nil, nil, invoker))
+	assert.Equal(t, "01J9ZC7W8M5Q3T6V2XK4N0R1BD", seen)
+
+	// 上游没有 ID 时生成新的
+	assert.NoError(t, RequestIDClientInterceptor()(context.Background(), method, nil, nil, nil, invoker))
+	assert.Len(t, seen, 26)
+	assert.NotEqual(t, "01J9ZC7W8M5Q3T6V2XK4N0R1BD", seen)
+}
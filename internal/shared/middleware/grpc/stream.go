@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// wrappedServerStream 替换流的 ctx，使拦截器写入的值对 handler 可见
+type wrappedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// countingServerStream 统计成功收发的消息数
+// 同一个流上 SendMsg 与 RecvMsg 各自只允许一个 goroutine 调用，计数无需加锁
+type countingServerStream struct {
+	grpc.ServerStream
+	sent     int
+	received int
+}
+
+func (s *countingServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent++
+	}
+	return err
+}
+
+func (s *countingServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received++
+	}
+	return err
+}
@@ -0,0 +1,162 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	_ "youlingserv/gen/go/adhoc/v1" // 注册带 (common.auth) 选项的方法描述符
+	"youlingserv/internal/shared/auth"
+	"youlingserv/pkg/config"
+)
+
+// fakeServerStream 只实现测试用到的方法，recv 条消息读完后 RecvMsg 返回 io.EOF
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	recv int
+	sent []interface{}
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	if s.recv == 0 {
+		return io.EOF
+	}
+	s.recv--
+	return nil
+}
+
+var testStreamInfo = &grpc.StreamServerInfo{FullMethod: "/adhoc.v1.AdhocService/WatchAccessLogs", IsServerStream: true}
+
+func TestStreamRecoveryInterceptor(t *testing.T) {
+	ss := &fakeServerStream{ctx: context.Background()}
+	err := StreamRecoveryInterceptor()(nil, ss, testStreamInfo, func(srv interface{}, ss grpc.ServerStream) error {
+		panic("boom")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestStreamMetricsInterceptor_CountsMessages(t *testing.T) {
+	ss := &fakeServerStream{ctx: context.Background(), recv: 2}
+	var counted *countingServerStream
+	err := StreamMetricsInterceptor()(nil, ss, testStreamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+		counted = stream.(*countingServerStream)
+		for {
+			if err := stream.RecvMsg(nil); err != nil {
+				require.ErrorIs(t, err, io.EOF)
+				break
+			}
+		}
+		for i := 0; i < 3; i++ {
+			require.NoError(t, stream.SendMsg(i))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, counted.sent)
+	assert.Equal(t, 2, counted.received, "the failed RecvMsg must not be counted")
+	assert.Len(t, ss.sent, 3)
+}
+
+func TestStreamAuthInterceptor(t *testing.T) {
+	authenticator, _, err := auth.NewAuthenticator(config.AuthConfig{Mode: auth.ModeHeader}, nil)
+	require.NoError(t, err)
+	client, _, err := auth.NewAuthClient(config.AuthConfig{})
+	require.NoError(t, err)
+	checker := auth.NewPermissionChecker(client)
+	rules, err := auth.NewMethodRules(config.AuthConfig{})
+	require.NoError(t, err)
+	interceptor := StreamAuthInterceptor(authenticator, checker, rules)
+
+	t.Run("method without rule", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user-id", "user123"))
+		info := &grpc.StreamServerInfo{FullMethod: "/adhoc.v1.AdhocService/Unknown"}
+		err := interceptor(nil, &fakeServerStream{ctx: ctx}, info, func(srv interface{}, stream grpc.ServerStream) error {
+			return nil
+		})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("missing user id", func(t *testing.T) {
+		ss := &fakeServerStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.MD{})}
+		called := false
+		err := interceptor(nil, ss, testStreamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+			called = true
+			return nil
+		})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.False(t, called)
+	})
+
+	t.Run("principal visible to handler", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user-id", "user123"))
+		ss := &fakeServerStream{ctx: ctx}
+		var principal *auth.Principal
+		err := interceptor(nil, ss, testStreamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+			principal, _ = auth.PrincipalFromContext(stream.Context())
+			return nil
+		})
+		require.NoError(t, err)
+		require.NotNil(t, principal)
+		assert.Equal(t, "user123", principal.Subject)
+	})
+	spiffeID, _ := url.Parse("spiffe://youling/api-gateway")
+	gatewayCtx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+		State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{spiffeID}}}},
+	}})
+
+	t.Run("client certificate without user credentials", func(t *testing.T) {
+		// 网关转发的无凭证请求不能以网关自身的身份执行
+		called := false
+		err := interceptor(nil, &fakeServerStream{ctx: gatewayCtx}, testStreamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+			called = true
+			return nil
+		})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		assert.False(t, called)
+	})
+
+	t.Run("service identity from client certificate", func(t *testing.T) {
+		authenticator, _, err := auth.NewAuthenticator(config.AuthConfig{
+			Mode: auth.ModeHeader,
+			MTLS: config.MTLSAuthConfig{ServicePrincipals: []string{"spiffe://youling/api-gateway"}},
+		}, nil)
+		require.NoError(t, err)
+		interceptor := StreamAuthInterceptor(authenticator, checker, rules)
+		ctx := gatewayCtx
+
+		var principal *auth.Principal
+		handler := func(srv interface{}, stream grpc.ServerStream) error {
+			principal, _ = auth.PrincipalFromContext(stream.Context())
+			return nil
+		}
+		require.NoError(t, interceptor(nil, &fakeServerStream{ctx: ctx}, testStreamInfo, handler))
+		assert.Equal(t, &auth.Principal{Subject: "spiffe://youling/api-gateway", Peer: "spiffe://youling/api-gateway"}, principal)
+
+		// 同时携带用户凭证时以用户身份判定，服务身份记录在 Peer 中
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("user-id", "user123"))
+		require.NoError(t, interceptor(nil, &fakeServerStream{ctx: ctx}, testStreamInfo, handler))
+		assert.Equal(t, &auth.Principal{Subject: "user123", Peer: "spiffe://youling/api-gateway"}, principal)
+	})
+}
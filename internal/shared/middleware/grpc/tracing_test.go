@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTracing_PropagatesAcrossGRPCHop(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	const method = "/adhoc.v1.AdhocService/Hello"
+	server := TracingInterceptor()
+	var serverSpan trace.SpanContext
+
+	// 客户端把 traceparent 写入 outgoing metadata，这里直接转成服务端的 incoming metadata
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		require.NotEmpty(t, md.Get("traceparent"))
+		assert.Equal(t, []string{"alice"}, md.Get("user-id"), "existing metadata is kept")
+
+		_, err := server(metadata.NewIncomingContext(context.Background(), md), nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				serverSpan = trace.SpanContextFromContext(ctx)
+				return nil, status.Error(codes.NotFound, "missing")
+			})
+		return err
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "user-id", "alice")
+	err := TracingClientInterceptor()(ctx, method, nil, nil, nil, invoker)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	srv, cli := spans[0], spans[1]
+	assert.Equal(t, "adhoc.v1.AdhocService/Hello", srv.Name())
+	assert.Equal(t, trace.SpanKindServer, srv.SpanKind())
+	assert.Equal(t, trace.SpanKindClient, cli.SpanKind())
+	assert.Equal(t, cli.SpanContext().TraceID(), srv.SpanContext().TraceID())
+	assert.Equal(t, cli.SpanContext().SpanID(), srv.Parent().SpanID())
+	assert.Equal(t, srv.SpanContext().SpanID(), serverSpan.SpanID(), "handler sees the server span")
+	assert.Equal(t, otelcodes.Error, srv.Status().Code)
+}
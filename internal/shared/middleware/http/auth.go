@@ -2,36 +2,94 @@ package http
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"youlingserv/internal/shared/auth"
+	"youlingserv/pkg/dto"
+	"youlingserv/pkg/log"
 
 	"github.com/cloudwego/hertz/pkg/app"
-	"github.com/cloudwego/hertz/pkg/common/utils"
+	"github.com/cloudwego/hertz/pkg/network"
+	"go.uber.org/zap"
 )
 
-func AuthMiddleware(checker *auth.PermissionChecker) app.HandlerFunc {
+// AuthMiddleware 按路由的授权规则认证并鉴权，未声明规则的路由一律拒绝，公开路由直接放行
+func AuthMiddleware(authenticator auth.Authenticator, checker *auth.PermissionChecker, rules *auth.RouteRules) app.HandlerFunc {
 	return func(ctx context.Context, c *app.RequestContext) {
-		userID := string(c.GetHeader("X-User-ID"))
-		if userID == "" {
-			c.JSON(401, utils.H{
-				"code": 401,
-				"msg":  "unauthorized: missing user ID",
-			})
+		// 未匹配到路由时 FullPath 为空，交给后续的 404 / 405 处理
+		fullPath := c.FullPath()
+		if fullPath == "" {
+			c.Next(ctx)
+			return
+		}
+		rule, ok := rules.Lookup(string(c.Method()), fullPath)
+		if !ok {
+			c.JSON(403, dto.ErrorResponse(ctx, 403, fmt.Sprintf("forbidden: no authorization rule for %s %s", c.Method(), fullPath)))
 			c.Abort()
 			return
 		}
+		if rule.Public {
+			c.Next(ctx)
+			return
+		}
 
-		err := checker.CheckAccess(ctx, userID, "api", "access")
+		cred := Credentials(c)
+		cred.Peer = peerIdentity(c)
+		principal, err := authenticator.Authenticate(ctx, cred)
+		if err != nil {
+			msg := "unauthorized: " + err.Error()
+			if errors.Is(err, auth.ErrMissingCredentials) {
+				c.Header("WWW-Authenticate", "Bearer")
+			}
+			c.JSON(401, dto.ErrorResponse(ctx, 401, msg))
+			c.Abort()
+			return
+		}
+
+		ctx = auth.WithPrincipal(ctx, principal)
+		ctx = log.WithFields(ctx, zap.String(log.FieldUserID, principal.Subject))
+		err = checker.CheckAccess(ctx, principal.Subject, rule.Resource, rule.Action)
+		if errors.Is(err, auth.ErrPermissionDenied) {
+			c.JSON(403, dto.ErrorResponse(ctx, 403, "forbidden: "+err.Error()))
+			c.Abort()
+			return
+		}
+		if errors.Is(err, auth.ErrAuthUnavailable) {
+			log.FromContext(ctx).Error("Permission check failed", zap.Error(err))
+			c.JSON(503, dto.ErrorResponse(ctx, 503, "permission service unavailable"))
+			c.Abort()
+			return
+		}
 		if err != nil {
-			c.JSON(403, utils.H{
-				"code": 403,
-				"msg":  "forbidden: " + err.Error(),
-			})
+			log.FromContext(ctx).Error("Permission check failed", zap.Error(err))
+			c.JSON(500, dto.ErrorResponse(ctx, 500, "permission check failed"))
 			c.Abort()
 			return
 		}
 
-		c.Set("userID", userID)
+		c.Set("userID", principal.Subject)
 		c.Next(ctx)
 	}
 }
+
+// Credentials 读取请求头中的凭证，网关转发到下游 gRPC 服务时原样透传；Peer 为连接属性，不在其中
+func Credentials(c *app.RequestContext) auth.Credentials {
+	return auth.Credentials{
+		Authorization: string(c.GetHeader("Authorization")),
+		UserID:        string(c.GetHeader("X-User-ID")),
+	}
+}
+
+// peerIdentity 取 mTLS 客户端证书标识的服务，监听未启用 TLS 或客户端未出示证书时为空
+func peerIdentity(c *app.RequestContext) string {
+	conn, ok := c.GetConn().(network.ConnTLSer)
+	if !ok {
+		return ""
+	}
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return ""
+	}
+	return auth.CertificateIdentity(certs[0])
+}
@@ -0,0 +1,22 @@
+package http
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"go.uber.org/zap"
+
+	"youlingserv/pkg/log"
+	"youlingserv/pkg/observability"
+)
+
+// LogContextMiddleware 把请求的方法与路由模板写入 ctx，后续 log.FromContext 输出的日志都带 rpc_method 字段
+func LogContextMiddleware() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		route := c.FullPath()
+		if route == "" {
+			route = observability.UnmatchedRoute
+		}
+		c.Next(log.WithFields(ctx, zap.String(log.FieldRPCMethod, string(c.Method())+" "+route)))
+	}
+}
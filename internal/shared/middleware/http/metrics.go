@@ -9,15 +9,24 @@ import (
 	"github.com/cloudwego/hertz/pkg/app"
 )
 
+// MetricsMiddleware 按路由模板（c.FullPath）记录请求数、耗时与处理中的请求数
+// 未匹配到路由的请求统一记为 observability.UnmatchedRoute，避免任意路径产生新的标签值
 func MetricsMiddleware() app.HandlerFunc {
 	return func(ctx context.Context, c *app.RequestContext) {
+		method := string(c.Method())
+		route := c.FullPath()
+		if route == "" {
+			route = observability.UnmatchedRoute
+		}
+
+		done := observability.HTTPRequestStarted(method, route)
+		defer done()
+
 		start := time.Now()
 		c.Next(ctx)
 		duration := time.Since(start)
-		path := string(c.Path())
-		method := string(c.Method())
 		statusCode := c.Response.StatusCode()
 
-		observability.RecordHTTPMetrics(method, path, statusCode, duration)
+		observability.RecordHTTPMetrics(ctx, method, route, statusCode, duration)
 	}
 }
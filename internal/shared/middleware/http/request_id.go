@@ -0,0 +1,19 @@
+package http
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"youlingserv/pkg/requestid"
+)
+
+// RequestIDMiddleware 沿用请求头 X-Request-ID（不合法时重新生成 ULID），写入 ctx 并回写到响应头
+// 放在中间件链最前面，被后续中间件拒绝的请求也带有请求 ID
+func RequestIDMiddleware() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		id := requestid.Ensure(string(c.GetHeader(requestid.Header)))
+		c.Header(requestid.Header, id)
+		c.Next(requestid.NewContext(ctx, id))
+	}
+}
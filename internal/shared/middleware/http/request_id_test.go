@@ -0,0 +1,56 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"youlingserv/pkg/dto"
+	"youlingserv/pkg/requestid"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	engine := route.NewEngine(config.NewOptions(nil))
+	engine.Use(RequestIDMiddleware())
+	engine.GET("/fail", func(ctx context.Context, c *app.RequestContext) {
+		c.JSON(404, dto.ErrorResponse(ctx, 404, "not found"))
+	})
+
+	cases := []struct {
+		name     string
+		incoming string
+		reuse    bool
+	}{
+		{name: "reuses caller id", incoming: "ticket-42.retry:1", reuse: true},
+		{name: "generates when missing"},
+		{name: "regenerates when invalid", incoming: "bad id\nwith newline"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var headers []ut.Header
+			if tc.incoming != "" {
+				headers = append(headers, ut.Header{Key: requestid.Header, Value: tc.incoming})
+			}
+			resp := ut.PerformRequest(engine, "GET", "/fail", nil, headers...).Result()
+
+			id := string(resp.Header.Peek(requestid.Header))
+			if tc.reuse {
+				assert.Equal(t, tc.incoming, id)
+			} else {
+				assert.Len(t, id, 26, "ULID")
+			}
+
+			var body dto.CommonDTO
+			require.NoError(t, json.Unmarshal(resp.Body(), &body))
+			assert.Equal(t, 404, body.Code)
+			assert.Equal(t, id, body.RequestID, "error body carries the same id as the header")
+		})
+	}
+}
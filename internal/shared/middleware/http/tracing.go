@@ -0,0 +1,69 @@
+package http
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/protocol"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"youlingserv/pkg/observability"
+)
+
+// TracingMiddleware 为每个请求创建 server span，上游带 traceparent 时接续其链路
+// span 名使用路由模板，与指标的 route 标签一致；后续中间件与 handler 通过 ctx 取得该 span
+func TracingMiddleware() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		ctx = observability.Propagator().Extract(ctx, headerCarrier{&c.Request.Header})
+
+		method := string(c.Method())
+		route := c.FullPath()
+		if route == "" {
+			route = observability.UnmatchedRoute
+		}
+		ctx, span := observability.Tracer().Start(ctx, method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", string(c.Path())),
+			),
+		)
+		defer span.End()
+
+		c.Next(ctx)
+
+		statusCode := c.Response.StatusCode()
+		span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+		if statusCode >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", statusCode))
+		}
+	}
+}
+
+// headerCarrier 让传播器读写 Hertz 的请求头
+type headerCarrier struct {
+	h *protocol.RequestHeader
+}
+
+var _ propagation.TextMapCarrier = headerCarrier{}
+
+func (c headerCarrier) Get(key string) string {
+	return c.h.Get(key)
+}
+
+func (c headerCarrier) Set(key, value string) {
+	c.h.Set(key, value)
+}
+
+func (c headerCarrier) Keys() []string {
+	var keys []string
+	c.h.VisitAll(func(k, _ []byte) {
+		keys = append(keys, string(k))
+	})
+	return keys
+}
@@ -0,0 +1,142 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/database"
+	"youlingserv/pkg/migrate"
+)
+
+const usage = `usage: <binary> migrate <command> [args]
+
+commands:
+  up [n]         执行 n 个未执行的迁移，缺省或 0 表示全部
+  down [n]       回滚最近的 n 个迁移，缺省为 1，0 表示全部
+  status         查看迁移执行状态
+  create <name>  在每个驱动目录下生成一对空迁移文件
+
+flags:
+`
+
+// Run 执行 migrate 子命令，args 为 "migrate" 之后的参数
+func Run(conf *config.Config, args []string) error {
+	return run(conf, args, os.Stdout)
+}
+
+func run(conf *config.Config, args []string, out io.Writer) error {
+	fset := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	fset.SetOutput(out)
+	dir := fset.String("dir", Dir, "migrate create 的输出目录")
+	fset.Usage = func() {
+		fmt.Fprint(out, usage)
+		fset.PrintDefaults()
+	}
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	if fset.NArg() == 0 {
+		fset.Usage()
+		return errors.New("migrate: missing command")
+	}
+
+	cmd, rest := fset.Arg(0), fset.Args()[1:]
+	if cmd == "create" {
+		if len(rest) != 1 {
+			return errors.New("migrate: usage: migrate create <name>")
+		}
+		now := time.Now()
+		for _, driver := range Drivers {
+			up, down, err := migrate.Create(filepath.Join(*dir, driver), rest[0], now)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "created %s\ncreated %s\n", up, down)
+		}
+		return nil
+	}
+
+	migrator, cleanup, err := newMigrator(conf.DBConf)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	switch cmd {
+	case "up":
+		steps, err := parseSteps(rest, 0)
+		if err != nil {
+			return err
+		}
+		done, err := migrator.Up(ctx, steps)
+		printMigrations(out, "applied", done)
+		return err
+	case "down":
+		steps, err := parseSteps(rest, 1)
+		if err != nil {
+			return err
+		}
+		done, err := migrator.Down(ctx, steps)
+		printMigrations(out, "reverted", done)
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied at " + s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(out, "%d_%s\t%s\n", s.Version, s.Name, state)
+		}
+		return nil
+	default:
+		fset.Usage()
+		return fmt.Errorf("migrate: unknown command %q", cmd)
+	}
+}
+
+// newMigrator 按 db 配置连接数据库并加载对应驱动的迁移
+func newMigrator(conf config.DBConfig) (*migrate.Migrator, func(), error) {
+	migrations, err := Load(conf.Driver)
+	if err != nil {
+		return nil, nil, err
+	}
+	db, cleanup, err := database.NewDB(database.NewConfig(conf))
+	if err != nil {
+		return nil, nil, err
+	}
+	return migrate.New(db, migrations), cleanup, nil
+}
+
+func parseSteps(args []string, def int) (int, error) {
+	if len(args) == 0 {
+		return def, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("migrate: invalid step count %q", args[0])
+	}
+	return n, nil
+}
+
+func printMigrations(out io.Writer, verb string, migrations []migrate.Migration) {
+	if len(migrations) == 0 {
+		fmt.Fprintln(out, "no migrations "+verb)
+		return
+	}
+	for _, m := range migrations {
+		fmt.Fprintf(out, "%s %d_%s\n", verb, m.Version, m.Name)
+	}
+}
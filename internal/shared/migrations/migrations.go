@@ -0,0 +1,35 @@
+// Package migrations 内置的版本化数据库迁移，按驱动分目录存放
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"youlingserv/pkg/database"
+	"youlingserv/pkg/migrate"
+)
+
+//go:embed mysql postgres sqlite
+var files embed.FS
+
+// Dir 迁移文件在仓库中的目录，migrate create 默认写入此处
+const Dir = "internal/shared/migrations"
+
+// Drivers 每个驱动对应一个迁移子目录
+var Drivers = []string{database.DriverMySQL, database.DriverPostgres, database.DriverSQLite}
+
+// Load 加载指定驱动的内置迁移，driver 为空时按 mysql 处理
+func Load(driver string) ([]migrate.Migration, error) {
+	if driver == "" {
+		driver = database.DriverMySQL
+	}
+	sub, err := fs.Sub(files, driver)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fs.ReadDir(sub, "."); err != nil {
+		return nil, fmt.Errorf("migrations: unsupported driver %q", driver)
+	}
+	return migrate.Load(sub)
+}
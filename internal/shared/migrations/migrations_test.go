@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"youlingserv/pkg/database"
+	"youlingserv/pkg/migrate"
+)
+
+// TestLoad_AllDrivers 每个驱动目录的迁移版本保持一致
+func TestLoad_AllDrivers(t *testing.T) {
+	var versions []int64
+	for _, driver := range Drivers {
+		migrations, err := Load(driver)
+		require.NoError(t, err, driver)
+		var vs []int64
+		for _, m := range migrations {
+			assert.NotEmpty(t, m.DownSQL, "%s %d_%s", driver, m.Version, m.Name)
+			vs = append(vs, m.Version)
+		}
+		if versions == nil {
+			versions = vs
+		}
+		assert.Equal(t, versions, vs, driver)
+	}
+
+	_, err := Load("mongodb")
+	assert.Error(t, err)
+}
+
+func TestSQLiteMigrations_UpDown(t *testing.T) {
+	db, err := database.Open(&database.Config{Driver: database.DriverSQLite, Database: ":memory:"})
+	require.NoError(t, err)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	migrations, err := Load(database.DriverSQLite)
+	require.NoError(t, err)
+	m := migrate.New(db, migrations)
+
+	_, err = m.Up(context.Background(), 0)
+	require.NoError(t, err)
+	for _, table := range []string{"users", "adhoc_users", "adhoc_access_logs", "api_keys"} {
+		assert.True(t, db.Migrator().HasTable(table), table)
+	}
+	assert.True(t, db.Migrator().HasIndex("users", "idx_users_email"))
+
+	_, err = m.Down(context.Background(), 0)
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasTable("users"))
+}
@@ -0,0 +1,11 @@
+CREATE TABLE users (
+    id         BIGINT       NOT NULL AUTO_INCREMENT,
+    username   VARCHAR(50)  NOT NULL,
+    email      VARCHAR(100) NOT NULL,
+    status     TINYINT      NOT NULL DEFAULT 1,
+    created_at DATETIME(3)  NULL,
+    updated_at DATETIME(3)  NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY idx_users_username (username),
+    UNIQUE KEY idx_users_email (email)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
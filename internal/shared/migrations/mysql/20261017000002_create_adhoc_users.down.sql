@@ -0,0 +1 @@
+DROP TABLE IF EXISTS adhoc_users;
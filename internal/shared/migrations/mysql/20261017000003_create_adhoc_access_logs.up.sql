@@ -0,0 +1,7 @@
+CREATE TABLE adhoc_access_logs (
+    id         BIGINT      NOT NULL AUTO_INCREMENT,
+    name       VARCHAR(50) NOT NULL,
+    action     VARCHAR(20) NOT NULL,
+    created_at DATETIME(3) NULL,
+    PRIMARY KEY (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
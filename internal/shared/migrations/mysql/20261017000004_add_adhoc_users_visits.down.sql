@@ -0,0 +1,4 @@
+ALTER TABLE adhoc_users
+    DROP INDEX idx_adhoc_users_name,
+    DROP COLUMN last_seen_at,
+    DROP COLUMN visit_count;
@@ -0,0 +1,4 @@
+ALTER TABLE adhoc_users
+    ADD COLUMN visit_count  BIGINT      NOT NULL DEFAULT 0,
+    ADD COLUMN last_seen_at DATETIME(3) NULL,
+    ADD UNIQUE KEY idx_adhoc_users_name (name);
@@ -0,0 +1,3 @@
+DROP INDEX idx_adhoc_access_logs_created_at ON adhoc_access_logs;
+DROP INDEX idx_adhoc_access_logs_action_created_at ON adhoc_access_logs;
+DROP INDEX idx_adhoc_access_logs_name_created_at ON adhoc_access_logs;
@@ -0,0 +1,16 @@
+CREATE TABLE api_keys (
+    id           BIGINT        NOT NULL AUTO_INCREMENT,
+    key_id       VARCHAR(32)   NOT NULL,
+    name         VARCHAR(100)  NOT NULL,
+    owner        VARCHAR(100)  NOT NULL,
+    scopes       VARCHAR(1000) NOT NULL,
+    hash         VARCHAR(100)  NOT NULL,
+    expires_at   DATETIME(3)   NULL,
+    last_used_at DATETIME(3)   NULL,
+    revoked_at   DATETIME(3)   NULL,
+    created_at   DATETIME(3)   NULL,
+    updated_at   DATETIME(3)   NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY idx_api_keys_key_id (key_id),
+    KEY idx_api_keys_owner (owner)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,11 @@
+CREATE TABLE users (
+    id         BIGSERIAL    PRIMARY KEY,
+    username   VARCHAR(50)  NOT NULL,
+    email      VARCHAR(100) NOT NULL,
+    status     SMALLINT     NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ  NULL,
+    updated_at TIMESTAMPTZ  NULL
+);
+
+CREATE UNIQUE INDEX idx_users_username ON users (username);
+CREATE UNIQUE INDEX idx_users_email ON users (email);
@@ -0,0 +1,4 @@
+CREATE TABLE adhoc_users (
+    id   BIGSERIAL   PRIMARY KEY,
+    name VARCHAR(50) NOT NULL
+);
@@ -0,0 +1,5 @@
+ALTER TABLE adhoc_users
+    ADD COLUMN visit_count  BIGINT      NOT NULL DEFAULT 0,
+    ADD COLUMN last_seen_at TIMESTAMPTZ NULL;
+
+CREATE UNIQUE INDEX idx_adhoc_users_name ON adhoc_users (name);
@@ -0,0 +1,11 @@
+CREATE TABLE users (
+    id         INTEGER      PRIMARY KEY AUTOINCREMENT,
+    username   VARCHAR(50)  NOT NULL,
+    email      VARCHAR(100) NOT NULL,
+    status     INTEGER      NOT NULL DEFAULT 1,
+    created_at DATETIME     NULL,
+    updated_at DATETIME     NULL
+);
+
+CREATE UNIQUE INDEX idx_users_username ON users (username);
+CREATE UNIQUE INDEX idx_users_email ON users (email);
@@ -0,0 +1,4 @@
+CREATE TABLE adhoc_users (
+    id   INTEGER     PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(50) NOT NULL
+);
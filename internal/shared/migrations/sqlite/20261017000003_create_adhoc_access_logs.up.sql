@@ -0,0 +1,6 @@
+CREATE TABLE adhoc_access_logs (
+    id         INTEGER     PRIMARY KEY AUTOINCREMENT,
+    name       VARCHAR(50) NOT NULL,
+    action     VARCHAR(20) NOT NULL,
+    created_at DATETIME    NULL
+);
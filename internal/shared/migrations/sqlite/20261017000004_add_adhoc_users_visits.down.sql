@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_adhoc_users_name;
+
+ALTER TABLE adhoc_users DROP COLUMN last_seen_at;
+ALTER TABLE adhoc_users DROP COLUMN visit_count;
@@ -0,0 +1,4 @@
+ALTER TABLE adhoc_users ADD COLUMN visit_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE adhoc_users ADD COLUMN last_seen_at DATETIME NULL;
+
+CREATE UNIQUE INDEX idx_adhoc_users_name ON adhoc_users (name);
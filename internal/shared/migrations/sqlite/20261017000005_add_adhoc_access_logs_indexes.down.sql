@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_adhoc_access_logs_created_at;
+DROP INDEX IF EXISTS idx_adhoc_access_logs_action_created_at;
+DROP INDEX IF EXISTS idx_adhoc_access_logs_name_created_at;
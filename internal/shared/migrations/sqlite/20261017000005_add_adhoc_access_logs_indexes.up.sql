@@ -0,0 +1,3 @@
+CREATE INDEX idx_adhoc_access_logs_name_created_at ON adhoc_access_logs (name, created_at);
+CREATE INDEX idx_adhoc_access_logs_action_created_at ON adhoc_access_logs (action, created_at);
+CREATE INDEX idx_adhoc_access_logs_created_at ON adhoc_access_logs (created_at);
@@ -0,0 +1,16 @@
+CREATE TABLE api_keys (
+    id           INTEGER       PRIMARY KEY AUTOINCREMENT,
+    key_id       VARCHAR(32)   NOT NULL,
+    name         VARCHAR(100)  NOT NULL,
+    owner        VARCHAR(100)  NOT NULL,
+    scopes       VARCHAR(1000) NOT NULL,
+    hash         VARCHAR(100)  NOT NULL,
+    expires_at   DATETIME      NULL,
+    last_used_at DATETIME      NULL,
+    revoked_at   DATETIME      NULL,
+    created_at   DATETIME      NULL,
+    updated_at   DATETIME      NULL
+);
+
+CREATE UNIQUE INDEX idx_api_keys_key_id ON api_keys (key_id);
+CREATE INDEX idx_api_keys_owner ON api_keys (owner);
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// APIKey 机器调用方使用的 API key，只保存 secret 的 bcrypt 哈希
+type APIKey struct {
+	ID         int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	KeyID      string     `gorm:"type:varchar(32);not null;uniqueIndex" json:"key_id"` // key 的公开部分，用于查找
+	Name       string     `gorm:"type:varchar(100);not null" json:"name"`
+	Owner      string     `gorm:"type:varchar(100);not null;index" json:"owner"` // 以该用户身份调用
+	Scopes     string     `gorm:"type:varchar(1000);not null" json:"scopes"`     // 空格分隔的 resource:action
+	Hash       string     `gorm:"type:varchar(100);not null" json:"-"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// ScopeList 拆分 Scopes
+func (k *APIKey) ScopeList() []string {
+	return strings.Fields(k.Scopes)
+}
+
+// Expired now 时刻是否已过期，未设置过期时间的 key 永不过期
+func (k *APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
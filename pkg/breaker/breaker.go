@@ -0,0 +1,137 @@
+// Package breaker 熔断器：连续失败达到阈值后断开，冷却后放行单个探测请求
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen 熔断器处于断开状态，请求被直接拒绝
+var ErrOpen = errors.New("circuit breaker is open")
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 10 * time.Second
+)
+
+// State 熔断器状态
+type State int
+
+const (
+	StateClosed   State = iota // 正常放行
+	StateOpen                  // 断开，拒绝所有请求
+	StateHalfOpen              // 冷却结束，只放行一个探测请求
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Breaker 连续 threshold 次失败后断开 openTimeout，之后进入半开状态放行一个探测请求：
+// 探测成功则闭合，失败则重新断开
+type Breaker struct {
+	threshold   int
+	openTimeout time.Duration
+	onChange    func(from, to State)
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// New 创建熔断器，threshold / openTimeout <= 0 时使用默认值；onChange 可为 nil，在持有锁时调用，不能阻塞
+func New(threshold int, openTimeout time.Duration, onChange func(from, to State)) *Breaker {
+	if threshold <= 0 {
+		threshold = defaultFailureThreshold
+	}
+	if openTimeout <= 0 {
+		openTimeout = defaultOpenTimeout
+	}
+	return &Breaker{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		onChange:    onChange,
+	}
+}
+
+// Allow 判断是否放行请求，放行后必须调用 Done 报告结果
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return ErrOpen
+		}
+		b.setState(StateHalfOpen)
+		fallthrough
+	case StateHalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Done 报告放行请求的结果，success 为 false 表示下游故障（业务层面的拒绝应视为成功）
+func (b *Breaker) Done(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen {
+		b.probing = false
+		if success {
+			b.failures = 0
+			b.setState(StateClosed)
+		} else {
+			b.trip()
+		}
+		return
+	}
+
+	if success {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == StateClosed && b.failures >= b.threshold {
+		b.trip()
+	}
+}
+
+// State 当前状态，冷却已结束但尚未有请求时仍报告 open
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *Breaker) trip() {
+	b.failures = 0
+	b.openedAt = time.Now()
+	b.setState(StateOpen)
+}
+
+func (b *Breaker) setState(to State) {
+	if b.state == to {
+		return
+	}
+	from := b.state
+	b.state = to
+	if b.onChange != nil {
+		b.onChange(from, to)
+	}
+}
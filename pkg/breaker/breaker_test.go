@@ -0,0 +1,42 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreaker(t *testing.T) {
+	var transitions []string
+	b := New(2, 20*time.Millisecond, func(from, to State) {
+		transitions = append(transitions, from.String()+"->"+to.String())
+	})
+
+	// 成功会清零连续失败计数
+	for _, ok := range []bool{false, true, false} {
+		require.NoError(t, b.Allow())
+		b.Done(ok)
+	}
+	assert.Equal(t, StateClosed, b.State())
+
+	require.NoError(t, b.Allow())
+	b.Done(false)
+	assert.Equal(t, StateOpen, b.State())
+	assert.ErrorIs(t, b.Allow(), ErrOpen)
+
+	// 冷却后只放行一个探测请求，探测失败重新断开
+	time.Sleep(25 * time.Millisecond)
+	require.NoError(t, b.Allow())
+	assert.ErrorIs(t, b.Allow(), ErrOpen)
+	b.Done(false)
+	assert.Equal(t, StateOpen, b.State())
+
+	time.Sleep(25 * time.Millisecond)
+	require.NoError(t, b.Allow())
+	b.Done(true)
+	assert.Equal(t, StateClosed, b.State())
+
+	assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}, transitions)
+}
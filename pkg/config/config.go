@@ -1,11 +1,12 @@
 package config
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
@@ -16,20 +17,232 @@ import (
 
 type (
 	Config struct {
-		LogConf LogConfig `mapstructure:"log"`
-		DBConf  DBConfig  `mapstructure:"db"`
+		LogConf         LogConfig         `mapstructure:"log"`
+		DBConf          DBConfig          `mapstructure:"db"`
+		AdhocClientConf AdhocClientConfig `mapstructure:"adhoc_client"`
+		ShutdownConf    ShutdownConfig    `mapstructure:"shutdown"`
+		GRPCServerConf  GRPCServerConfig  `mapstructure:"grpc_server"`
+		HTTPServerConf  HTTPServerConfig  `mapstructure:"http_server"`
+		AccessLogConf   AccessLogConfig   `mapstructure:"access_log"`
+		AuthConf        AuthConfig        `mapstructure:"auth"`
+		MetricsConf     MetricsConfig     `mapstructure:"metrics"`
+		TracingConf     TracingConfig     `mapstructure:"tracing"`
 	}
 
+	// LogConfig 日志配置，level 可在运行时随配置文件热加载调整，其余字段修改后需重启
 	LogConfig struct {
-		Level string `mapstructure:"level"`
+		Level    string            `mapstructure:"level"`     // debug / info / warn / error
+		Format   string            `mapstructure:"format"`    // json / console
+		TimeZone string            `mapstructure:"time_zone"` // 日志时间的时区，如 Asia/Shanghai，为空时使用本地时区
+		Output   string            `mapstructure:"output"`    // stdout / stderr / file
+		File     LogFileConfig     `mapstructure:"file"`
+		Sampling LogSamplingConfig `mapstructure:"sampling"`
+	}
+
+	// LogFileConfig 输出到文件时的路径与滚动策略
+	LogFileConfig struct {
+		Path       string `mapstructure:"path"`
+		MaxSizeMB  int    `mapstructure:"max_size_mb"`  // 单个文件的大小上限（MB），超过后滚动
+		MaxAgeDays int    `mapstructure:"max_age_days"` // 旧文件保留天数，0 不按时间清理
+		MaxBackups int    `mapstructure:"max_backups"`  // 旧文件保留个数，0 不按个数清理
+		Compress   bool   `mapstructure:"compress"`     // gzip 压缩旧文件
+	}
+
+	// LogSamplingConfig 每秒内同级别同消息的日志，前 initial 条全部输出，之后每 thereafter 条输出一条；initial 为 0 不采样
+	LogSamplingConfig struct {
+		Initial    int `mapstructure:"initial"`
+		Thereafter int `mapstructure:"thereafter"`
 	}
 
 	DBConfig struct {
+		Driver   string `mapstructure:"driver"` // mysql / postgres / sqlite
 		Host     string `mapstructure:"host"`
 		Port     int    `mapstructure:"port"`
 		User     string `mapstructure:"user"`
-		Pwd      string `mapstructure:"pwd"`
-		DataBase string `mapstructure:"database"`
+		Pwd      string `mapstructure:"pwd"`      // 建议通过环境变量 YOULING_DB_PWD 注入
+		DataBase string `mapstructure:"database"` // SQLite 下为数据库文件路径
+		SSLMode  string `mapstructure:"ssl_mode"` // 仅 PostgreSQL 使用
+
+		MaxOpenConns    int           `mapstructure:"max_open_conns"`
+		MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+		ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+		ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+		ConnectRetries  int           `mapstructure:"connect_retries"` // 启动时 Ping 失败的重试次数
+		RetryBackoff    time.Duration `mapstructure:"retry_backoff"`   // 首次重试等待时间，之后指数退避
+		TxRetries       int           `mapstructure:"tx_retries"`      // 事务死锁/序列化失败时的重试次数，负数关闭
+
+		Replicas                   []DBReplicaConfig `mapstructure:"replicas"`                      // 只读副本，查询语句路由到副本
+		ReplicaHealthCheckInterval time.Duration     `mapstructure:"replica_health_check_interval"` // 副本健康检查间隔
+	}
+
+	// DBReplicaConfig 只读副本配置，空字段沿用主库配置
+	DBReplicaConfig struct {
+		Host string `mapstructure:"host"`
+		Port int    `mapstructure:"port"`
+		User string `mapstructure:"user"`
+		Pwd  string `mapstructure:"pwd"`
+	}
+
+	// AdhocClientConfig 网关访问 adhoc-server 的 gRPC 客户端配置
+	AdhocClientConfig struct {
+		Target   string        `mapstructure:"target"`    // adhoc-server 地址，如 localhost:50051
+		PoolSize int           `mapstructure:"pool_size"` // 连接池大小
+		Timeout  time.Duration `mapstructure:"timeout"`   // 单次调用超时
+		TLS      TLSConfig     `mapstructure:"tls"`       // 连接 adhoc-server 的 TLS，配置 cert_file 时出示客户端证书（mTLS）
+	}
+
+	// AccessLogConfig adhoc 访问日志异步写入配置
+	AccessLogConfig struct {
+		QueueSize      int           `mapstructure:"queue_size"`      // 队列容量
+		BatchSize      int           `mapstructure:"batch_size"`      // 单次批量插入的最大条数
+		FlushInterval  time.Duration `mapstructure:"flush_interval"`  // 未攒满一批时的最长等待时间
+		OverflowPolicy string        `mapstructure:"overflow_policy"` // 队列满时：block / drop_oldest / error
+		WatchBuffer    int           `mapstructure:"watch_buffer"`    // WatchAccessLogs 每个订阅者的缓冲条数，满了即断开
+	}
+
+	// AuthConfig 调用方认证配置
+	AuthConfig struct {
+		Mode string     `mapstructure:"mode"` // jwt：校验 Bearer Token；header：信任 X-User-ID / user-id，仅限本地开发
+		JWT  JWTConfig  `mapstructure:"jwt"`
+		RBAC RBACConfig `mapstructure:"rbac"`
+		// Remote 配置了 target 时改由外部权限服务（auth.v1.PermissionService）判定，优先于 rbac
+		Remote  RemoteAuthConfig `mapstructure:"remote"`
+		APIKeys APIKeyConfig     `mapstructure:"api_keys"`
+		MTLS    MTLSAuthConfig   `mapstructure:"mtls"`
+
+		HTTPRoutes  []HTTPRouteRule  `mapstructure:"http_routes"`  // 网关手写路由的授权规则，转码路由沿用 RPC 的 (common.auth) 选项
+		GRPCMethods []GRPCMethodRule `mapstructure:"grpc_methods"` // 无法修改 proto 的 gRPC 方法（反射、健康检查等）的授权规则
+	}
+
+	// RemoteAuthConfig 外部权限服务客户端配置
+	RemoteAuthConfig struct {
+		Target           string        `mapstructure:"target"`            // 权限服务地址，如 localhost:50061
+		Timeout          time.Duration `mapstructure:"timeout"`           // 单次调用超时
+		Retries          int           `mapstructure:"retries"`           // 超时 / 不可用时的重试次数
+		RetryBackoff     time.Duration `mapstructure:"retry_backoff"`     // 首次重试等待时间，之后指数退避
+		FailOpen         bool          `mapstructure:"fail_open"`         // 权限服务不可用时放行（true）还是拒绝（false）
+		AllowCacheTTL    time.Duration `mapstructure:"allow_cache_ttl"`   // 允许结果的缓存时间，0 不缓存
+		DenyCacheTTL     time.Duration `mapstructure:"deny_cache_ttl"`    // 拒绝结果的缓存时间，0 不缓存
+		CacheSize        int           `mapstructure:"cache_size"`        // 每种结果缓存的条目上限
+		BreakerThreshold int           `mapstructure:"breaker_threshold"` // 连续失败多少次后熔断
+		BreakerTimeout   time.Duration `mapstructure:"breaker_timeout"`   // 熔断持续时间，之后放行一个探测请求
+	}
+
+	// APIKeyConfig 机器调用方 API key 配置
+	APIKeyConfig struct {
+		DefaultTTL       time.Duration `mapstructure:"default_ttl"`        // 创建时未指定有效期时使用，0 表示永不过期
+		MaxTTL           time.Duration `mapstructure:"max_ttl"`            // 有效期上限，0 不限制
+		LastUsedInterval time.Duration `mapstructure:"last_used_interval"` // 最近使用时间的最小更新间隔，避免每个请求都写库
+	}
+
+	// MTLSAuthConfig 以 mTLS 客户端证书身份调用的配置
+	MTLSAuthConfig struct {
+		// ServicePrincipals 请求未携带用户凭证时，允许以客户端证书身份（SPIFFE ID 或 CN）调用的服务
+		// 不在名单中的服务必须转发用户凭证，避免其代为转发的无凭证请求以服务自身的权限执行
+		ServicePrincipals []string `mapstructure:"service_principals"`
+	}
+
+	// HTTPRouteRule HTTP 路由的授权规则，public 为 true 时跳过认证与鉴权
+	HTTPRouteRule struct {
+		Method   string `mapstructure:"method"` // GET / POST ...
+		Path     string `mapstructure:"path"`   // 与注册路由时的模式一致，如 /api/v1/users/:id
+		Resource string `mapstructure:"resource"`
+		Action   string `mapstructure:"action"`
+		Public   bool   `mapstructure:"public"`
+	}
+
+	// GRPCMethodRule gRPC 方法的授权规则，method 为全方法名，支持 * 通配
+	GRPCMethodRule struct {
+		Method   string `mapstructure:"method"` // 如 /grpc.reflection.v1.ServerReflection/*
+		Resource string `mapstructure:"resource"`
+		Action   string `mapstructure:"action"`
+		Public   bool   `mapstructure:"public"`
+	}
+
+	// RBACConfig 基于策略文件的权限判定配置
+	RBACConfig struct {
+		PolicyFile string        `mapstructure:"policy_file"` // YAML 策略文件，修改后自动重新加载；为空时放行所有已认证调用方
+		CacheTTL   time.Duration `mapstructure:"cache_ttl"`   // 判定结果缓存时间，0 关闭缓存
+		CacheSize  int           `mapstructure:"cache_size"`  // 缓存条目上限
+	}
+
+	// JWTConfig JWT 校验配置，签名密钥来自 secret（HS256）或 JWKS（文件或 URL，支持轮换）
+	JWTConfig struct {
+		Algorithms          []string      `mapstructure:"algorithms"`            // 允许的签名算法，默认 HS256 / RS256 / EdDSA
+		Secret              string        `mapstructure:"secret"`                // HS256 共享密钥，建议通过 YOULING_AUTH_JWT_SECRET 注入
+		JWKSFile            string        `mapstructure:"jwks_file"`             // 本地 JWKS 文件路径
+		JWKSURL             string        `mapstructure:"jwks_url"`              // 远程 JWKS 地址，与 jwks_file 二选一
+		JWKSRefreshInterval time.Duration `mapstructure:"jwks_refresh_interval"` // 定期重新加载 JWKS 的间隔
+		Issuer              string        `mapstructure:"issuer"`                // 非空时校验 iss
+		Audience            string        `mapstructure:"audience"`              // 非空时校验 aud
+		Leeway              time.Duration `mapstructure:"leeway"`                // 校验 exp / nbf 时允许的时钟偏差
+		RolesClaim          string        `mapstructure:"roles_claim"`           // 角色所在的 claim，默认 roles
+		TenantClaim         string        `mapstructure:"tenant_claim"`          // 租户所在的 claim，默认 tenant
+	}
+
+	// MetricsConfig Prometheus 指标配置，/metrics 的监听地址分别在 grpc_server / http_server 中配置
+	MetricsConfig struct {
+		LatencyBuckets []float64 `mapstructure:"latency_buckets"` // 请求耗时直方图的分桶上界（秒），为空时使用 Prometheus 默认分桶
+	}
+
+	// TracingConfig OpenTelemetry 链路追踪配置，服务名由各进程自行指定
+	TracingConfig struct {
+		Exporter    string  `mapstructure:"exporter"`     // none / otlp / stdout / file，为空等同 none
+		Endpoint    string  `mapstructure:"endpoint"`     // OTLP gRPC 收集器地址，如 localhost:4317
+		Insecure    bool    `mapstructure:"insecure"`     // 以明文连接收集器
+		FilePath    string  `mapstructure:"file_path"`    // exporter 为 file 时写入的文件，每行一个 span（JSON）
+		SampleRatio float64 `mapstructure:"sample_ratio"` // 无上游采样决定时的采样比例，0~1；有上游时沿用上游决定
+	}
+
+	// ShutdownConfig 优雅关闭配置
+	ShutdownConfig struct {
+		DrainTimeout time.Duration `mapstructure:"drain_timeout"` // 等待存量请求结束的总时限
+	}
+
+	// GRPCServerConfig gRPC 服务器配置，零值字段使用 gRPC 默认值
+	GRPCServerConfig struct {
+		Addr                 string              `mapstructure:"addr"`                   // 监听地址，如 :50051
+		MaxRecvMsgSize       int                 `mapstructure:"max_recv_msg_size"`      // 单条请求消息上限（字节）
+		MaxSendMsgSize       int                 `mapstructure:"max_send_msg_size"`      // 单条响应消息上限（字节）
+		ConnectionTimeout    time.Duration       `mapstructure:"connection_timeout"`     // 连接握手超时
+		MaxConcurrentStreams uint32              `mapstructure:"max_concurrent_streams"` // 单连接最大并发流
+		Keepalive            GRPCKeepaliveConfig `mapstructure:"keepalive"`
+		TLS                  TLSConfig           `mapstructure:"tls"`
+		MetricsAddr          string              `mapstructure:"metrics_addr"` // 独立的 /metrics 监听地址，为空时不暴露
+	}
+
+	// GRPCKeepaliveConfig 对应 keepalive.ServerParameters 与 keepalive.EnforcementPolicy
+	GRPCKeepaliveConfig struct {
+		Time                  time.Duration `mapstructure:"time"`                     // 空闲多久后发送 ping
+		Timeout               time.Duration `mapstructure:"timeout"`                  // ping 无响应多久后关闭连接
+		MaxConnectionIdle     time.Duration `mapstructure:"max_connection_idle"`      // 空闲连接最长保留时间
+		MaxConnectionAge      time.Duration `mapstructure:"max_connection_age"`       // 连接最长存活时间
+		MaxConnectionAgeGrace time.Duration `mapstructure:"max_connection_age_grace"` // 到期后等待存量请求的时间
+		MinTime               time.Duration `mapstructure:"min_time"`                 // 允许客户端 ping 的最小间隔
+		PermitWithoutStream   bool          `mapstructure:"permit_without_stream"`    // 无活跃流时是否允许 ping
+	}
+
+	// HTTPServerConfig Hertz 服务器配置，零值字段使用 Hertz 默认值
+	HTTPServerConfig struct {
+		Addr               string        `mapstructure:"addr"`                  // 监听地址，如 0.0.0.0:8080
+		MaxRequestBodySize int           `mapstructure:"max_request_body_size"` // 请求体上限（字节）
+		ReadTimeout        time.Duration `mapstructure:"read_timeout"`
+		WriteTimeout       time.Duration `mapstructure:"write_timeout"`
+		IdleTimeout        time.Duration `mapstructure:"idle_timeout"`
+		KeepAlive          bool          `mapstructure:"keep_alive"` // 是否启用 HTTP keep-alive
+		TLS                TLSConfig     `mapstructure:"tls"`
+		MetricsAddr        string        `mapstructure:"metrics_addr"` // 独立的 /metrics 监听地址，为空时不暴露
+	}
+
+	// TLSConfig 服务端与客户端共用的 TLS 配置，证书文件变化后自动重新加载
+	TLSConfig struct {
+		Enabled        bool          `mapstructure:"enabled"`
+		CertFile       string        `mapstructure:"cert_file"`       // 本端证书（PEM，可附带中间证书），服务端必填
+		KeyFile        string        `mapstructure:"key_file"`        // 本端私钥
+		CAFile         string        `mapstructure:"ca_file"`         // 服务端用于校验客户端证书，客户端用于校验服务端证书（为空时使用系统根证书）
+		ClientAuth     string        `mapstructure:"client_auth"`     // 仅服务端：none / optional / require，require 即 mTLS
+		ServerName     string        `mapstructure:"server_name"`     // 仅客户端：校验服务端证书时使用的名称，为空时取 target 的主机名
+		ReloadInterval time.Duration `mapstructure:"reload_interval"` // 检查证书文件变化的间隔
 	}
 )
 
@@ -37,9 +250,49 @@ var (
 	conf Config
 	once sync.Once
 
+	changeMu    sync.Mutex
+	changeHooks []func(*Config)
+
 	CmdConfigName string = "config.yml"
+
+	// EnvPrefix 环境变量前缀，配置项 a.b_c 对应 YOULING_A_B_C
+	EnvPrefix string = "YOULING"
 )
 
+// envAliases 常用配置项的短环境变量名
+var envAliases = map[string]string{
+	"grpc_server.addr": "YOULING_GRPC_ADDR",
+	"http_server.addr": "YOULING_HTTP_ADDR",
+	"db.pwd":           "YOULING_DB_PASSWORD",
+}
+
+// setDefaults 设置配置文件缺省时的默认值
+func setDefaults() {
+	viper.SetDefault("log.level", "info")
+	viper.SetDefault("log.time_zone", "Asia/Shanghai")
+	viper.SetDefault("db.driver", "mysql")
+	viper.SetDefault("grpc_server.addr", ":50051")
+	viper.SetDefault("http_server.addr", "0.0.0.0:8080")
+	viper.SetDefault("http_server.max_request_body_size", 4*1024*1024)
+	viper.SetDefault("http_server.keep_alive", true)
+	viper.SetDefault("auth.mode", "jwt")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+}
+
+// bindEnv 开启环境变量覆盖
+func bindEnv() error {
+	viper.SetEnvPrefix(EnvPrefix)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+
+	for key, env := range envAliases {
+		if err := viper.BindEnv(key, env, strings.ToUpper(EnvPrefix+"_"+strings.ReplaceAll(key, ".", "_"))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func InitLocalConfig(cwd ...string) error {
 	// 用变长参数实现唯一入参默认值
 	var path string
@@ -53,10 +306,14 @@ func InitLocalConfig(cwd ...string) error {
 		path = cwd[0]
 	}
 	confPath := filepath.Join(path, CmdConfigName)
-	log.GetLogger().Info(fmt.Sprintf("Loading config from %s", confPath))
+	log.GetLogger().Info("Loading config", zap.String("file", confPath))
 
 	viper.SetConfigFile(confPath)
 	viper.SetConfigType("yaml")
+	setDefaults()
+	if err = bindEnv(); err != nil {
+		return err
+	}
 	if err = viper.ReadInConfig(); err != nil {
 		log.GetLogger().Fatal(err.Error())
 	}
@@ -68,14 +325,30 @@ func InitLocalConfig(cwd ...string) error {
 	// 监控配置文件变化
 	viper.WatchConfig()
 	viper.OnConfigChange(func(e fsnotify.Event) {
-		log.GetLogger().Info("Config file changed:", zap.Any("file", e.Name))
+		log.GetLogger().Info("Config file changed", zap.String("file", e.Name))
 		if err := viper.Unmarshal(&conf); err != nil {
-			log.GetLogger().Fatal("Unable to decode into struct: %v", zap.Any("error", err))
+			log.GetLogger().Fatal("Unable to decode config", zap.Error(err))
+		}
+		changeMu.Lock()
+		hooks := changeHooks
+		changeMu.Unlock()
+		for _, fn := range hooks {
+			fn(&conf)
 		}
 	})
+
+	// 已显式初始化，GetConfig 不再按当前目录重复加载
+	once.Do(func() {})
 	return nil
 }
 
+// OnChange 注册配置文件热加载后的回调，按注册顺序在重新解析配置后调用
+func OnChange(fn func(*Config)) {
+	changeMu.Lock()
+	defer changeMu.Unlock()
+	changeHooks = append(changeHooks, fn)
+}
+
 // GetConfig 获取单例
 func GetConfig() *Config {
 	once.Do(func() {
@@ -10,3 +10,20 @@ func TestConfig(t *testing.T) {
 	}
 	t.Logf("config: %v", GetConfig())
 }
+
+func TestConfig_EnvOverride(t *testing.T) {
+	t.Setenv("YOULING_HTTP_ADDR", "127.0.0.1:18080")
+	t.Setenv("YOULING_GRPC_SERVER_MAX_CONCURRENT_STREAMS", "42")
+
+	if err := InitLocalConfig("../../"); err != nil {
+		t.Fatal(err)
+	}
+
+	conf := GetConfig()
+	if conf.HTTPServerConf.Addr != "127.0.0.1:18080" {
+		t.Errorf("http addr = %q, want env override", conf.HTTPServerConf.Addr)
+	}
+	if conf.GRPCServerConf.MaxConcurrentStreams != 42 {
+		t.Errorf("max concurrent streams = %d, want env override", conf.GRPCServerConf.MaxConcurrentStreams)
+	}
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"youlingserv/pkg/log"
+)
+
+// WatchFile 用独立的 viper 实例加载 YAML 文件到 T，之后文件每次变化都重新解析并回调 onChange
+// 解析失败或 onChange 返回错误时记录日志，调用方应保留上一个版本；首次加载失败直接返回错误
+// 注意 viper 会把 map 的键转成小写，大小写敏感的标识（用户 ID 等）应放在列表的字段里
+func WatchFile[T any](path string, onChange func(T) error) (T, error) {
+	var out T
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return out, err
+	}
+	if err := v.Unmarshal(&out); err != nil {
+		return out, fmt.Errorf("decode %s: %w", path, err)
+	}
+
+	v.OnConfigChange(func(e fsnotify.Event) {
+		var next T
+		if err := v.Unmarshal(&next); err != nil {
+			log.GetLogger().Error("Failed to reload file, keeping previous version", zap.String("file", path), zap.Error(err))
+			return
+		}
+		if err := onChange(next); err != nil {
+			log.GetLogger().Error("Rejected reload, keeping previous version", zap.String("file", path), zap.Error(err))
+			return
+		}
+		log.GetLogger().Info("Reloaded file", zap.String("file", e.Name))
+	})
+	v.WatchConfig()
+	return out, nil
+}
@@ -0,0 +1,209 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youlingserv/pkg/log"
+)
+
+// 支持的数据库驱动
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// Config 与驱动无关的数据库连接配置
+type Config struct {
+	Driver   string // mysql / postgres / sqlite，默认 mysql
+	Host     string
+	Port     int
+	User     string
+	Password string
+	Database string // SQLite 下为数据库文件路径，":memory:" 表示内存库
+	SSLMode  string // 仅 PostgreSQL 使用，默认 disable
+
+	// 连接池，零值使用 database/sql 默认值
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// 启动时 Ping 的重试次数与初始退避时间（每次翻倍，最长 maxRetryBackoff）
+	ConnectRetries int
+	RetryBackoff   time.Duration
+
+	// 事务遇到死锁或序列化失败时的重试次数，0 使用默认值 3，负数关闭重试
+	TxRetries int
+
+	// 只读副本，为空时所有语句都走主库
+	Replicas            []Replica
+	HealthCheckInterval time.Duration // 副本健康检查间隔，默认 10s
+}
+
+// Replica 只读副本的连接信息，空字段沿用主库配置
+type Replica struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+}
+
+// replicaConfig 以主库配置为基础生成副本的连接配置
+func (cfg *Config) replicaConfig(r Replica) *Config {
+	rc := *cfg
+	rc.Replicas = nil
+	if r.Host != "" {
+		rc.Host = r.Host
+	}
+	if r.Port != 0 {
+		rc.Port = r.Port
+	}
+	if r.User != "" {
+		rc.User = r.User
+	}
+	if r.Password != "" {
+		rc.Password = r.Password
+	}
+	return &rc
+}
+
+const (
+	pingTimeout     = 5 * time.Second
+	maxRetryBackoff = 30 * time.Second
+)
+
+// Open 按 cfg.Driver 选择驱动打开数据库连接，配置连接池并等待数据库就绪
+// 配置了只读副本时注册 Resolver，读语句自动路由到副本
+func Open(cfg *Config) (*gorm.DB, error) {
+	db, sqlDB, err := openPool(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pingWithRetry(db, cfg.ConnectRetries, cfg.RetryBackoff); err != nil {
+		_ = sqlDB.Close()
+		return nil, fmt.Errorf("failed to connect to %s database: %w", driverName(cfg), err)
+	}
+
+	if len(cfg.Replicas) > 0 {
+		resolver, err := newResolver(cfg)
+		if err != nil {
+			_ = sqlDB.Close()
+			return nil, fmt.Errorf("failed to open %s replicas: %w", driverName(cfg), err)
+		}
+		if err := db.Use(resolver); err != nil {
+			_ = resolver.Close()
+			_ = sqlDB.Close()
+			return nil, fmt.Errorf("failed to register %s replicas: %w", driverName(cfg), err)
+		}
+	}
+
+	return db, nil
+}
+
+// Close 关闭主库与副本的连接池
+func Close(db *gorm.DB) error {
+	var errs []error
+	if plugin, ok := db.Config.Plugins[resolverName]; ok {
+		errs = append(errs, plugin.(*Resolver).Close())
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	errs = append(errs, sqlDB.Close())
+	return errors.Join(errs...)
+}
+
+// openPool 创建 gorm 实例并配置连接池，不检查连通性
+func openPool(cfg *Config) (*gorm.DB, *sql.DB, error) {
+	dialector, err := newDialector(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 关闭 gorm 的自动 Ping，由调用方负责等待数据库就绪
+	db, err := gorm.Open(dialector, &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s database: %w", driverName(cfg), err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get %s connection pool: %w", driverName(cfg), err)
+	}
+	if cfg.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+	if cfg.ConnMaxIdleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	}
+	if driverName(cfg) == DriverSQLite && isSQLiteMemory(cfg.Database) {
+		// 每条连接都会得到一个独立的内存库，必须限制为单连接
+		sqlDB.SetMaxOpenConns(1)
+	}
+	return db, sqlDB, nil
+}
+
+// newDialector 根据驱动构建 gorm Dialector
+func newDialector(cfg *Config) (gorm.Dialector, error) {
+	switch driverName(cfg) {
+	case DriverMySQL:
+		return mysqlDialector(cfg), nil
+	case DriverPostgres:
+		return postgresDialector(cfg), nil
+	case DriverSQLite:
+		return sqliteDialector(cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", cfg.Driver)
+	}
+}
+
+func driverName(cfg *Config) string {
+	if cfg.Driver == "" {
+		return DriverMySQL
+	}
+	return cfg.Driver
+}
+
+// pingWithRetry 按指数退避重试 Ping，直到成功或用完重试次数
+func pingWithRetry(db *gorm.DB, retries int, backoff time.Duration) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+		err = sqlDB.PingContext(ctx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt >= retries {
+			return err
+		}
+
+		log.FromContext(ctx).Warn("Database not ready, retrying",
+			zap.Int("attempt", attempt+1), zap.Int("max_attempts", retries+1), zap.Duration("wait", backoff), zap.Error(err))
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMySQLDSN(t *testing.T) {
+	dsn := mysqlDSN(&Config{Host: "db", Port: 3306, User: "root", Password: "p@ss/word", Database: "youlingserv"})
+	assert.Contains(t, dsn, "root:p@ss/word@tcp(db:3306)/youlingserv?")
+	assert.Contains(t, dsn, "charset=utf8mb4")
+	assert.Contains(t, dsn, "parseTime=true")
+}
+
+func TestPostgresDSN(t *testing.T) {
+	dsn := postgresDSN(&Config{Host: "db", Port: 5432, User: "app", Password: "p@ss word", Database: "youlingserv"})
+	assert.Equal(t, "postgres://app:p%40ss%20word@db:5432/youlingserv?sslmode=disable", dsn)
+}
+
+func TestOpen_SQLiteMemory(t *testing.T) {
+	db, err := Open(&Config{Driver: DriverSQLite, Database: ":memory:"})
+	require.NoError(t, err)
+	sqlDB, _ := db.DB()
+	defer sqlDB.Close()
+
+	type item struct {
+		ID   int64
+		Name string
+	}
+	require.NoError(t, db.AutoMigrate(&item{}))
+	require.NoError(t, db.Create(&item{Name: "a"}).Error)
+
+	var count int64
+	require.NoError(t, db.Model(&item{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestOpen_UnsupportedDriver(t *testing.T) {
+	_, err := Open(&Config{Driver: "mongodb"})
+	assert.ErrorContains(t, err, "unsupported database driver")
+}
@@ -2,27 +2,27 @@ package database
 
 import (
 	"fmt"
+	"time"
 
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
-type MySQLConfig struct {
-	Host     string
-	Port     int
-	User     string
-	Password string
-	Database string
+// mysqlDSN 构建 MySQL DSN，用户名密码中的特殊字符由驱动负责转义
+func mysqlDSN(cfg *Config) string {
+	dc := mysqldriver.NewConfig()
+	dc.User = cfg.User
+	dc.Passwd = cfg.Password
+	dc.Net = "tcp"
+	dc.Addr = fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+	dc.DBName = cfg.Database
+	dc.ParseTime = true
+	dc.Loc = time.Local
+	dc.Params = map[string]string{"charset": "utf8mb4"}
+	return dc.FormatDSN()
 }
 
-func NewMySQLConnection(cfg *MySQLConfig) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Database)
-
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to MySQL: %w", err)
-	}
-
-	return db, nil
+func mysqlDialector(cfg *Config) gorm.Dialector {
+	return mysql.Open(mysqlDSN(cfg))
 }
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"fmt"
+	"net/url"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// postgresDSN 构建 PostgreSQL 连接 URL
+func postgresDSN(cfg *Config) string {
+	sslMode := cfg.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Password),
+		Host:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		Path:     "/" + cfg.Database,
+		RawQuery: url.Values{"sslmode": {sslMode}}.Encode(),
+	}
+	return u.String()
+}
+
+func postgresDialector(cfg *Config) gorm.Dialector {
+	return postgres.Open(postgresDSN(cfg))
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/log"
+)
+
+// NewConfig 由配置文件中的 db 段构建连接配置
+func NewConfig(conf config.DBConfig) *Config {
+	replicas := make([]Replica, 0, len(conf.Replicas))
+	for _, r := range conf.Replicas {
+		replicas = append(replicas, Replica{Host: r.Host, Port: r.Port, User: r.User, Password: r.Pwd})
+	}
+
+	return &Config{
+		Driver:          conf.Driver,
+		Host:            conf.Host,
+		Port:            conf.Port,
+		User:            conf.User,
+		Password:        conf.Pwd,
+		Database:        conf.DataBase,
+		SSLMode:         conf.SSLMode,
+		MaxOpenConns:    conf.MaxOpenConns,
+		MaxIdleConns:    conf.MaxIdleConns,
+		ConnMaxLifetime: conf.ConnMaxLifetime,
+		ConnMaxIdleTime: conf.ConnMaxIdleTime,
+		ConnectRetries:  conf.ConnectRetries,
+		RetryBackoff:    conf.RetryBackoff,
+		TxRetries:       conf.TxRetries,
+
+		Replicas:            replicas,
+		HealthCheckInterval: conf.ReplicaHealthCheckInterval,
+	}
+}
+
+// NewDB Wire provider：打开数据库连接，返回的 cleanup 负责关闭主库与副本连接池
+func NewDB(cfg *Config) (*gorm.DB, func(), error) {
+	db, err := Open(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanup := func() {
+		if err := Close(db); err != nil {
+			log.GetLogger().Error("Failed to close database", zap.Error(err))
+		}
+	}
+	return db, cleanup, nil
+}
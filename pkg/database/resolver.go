@@ -0,0 +1,189 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youlingserv/pkg/log"
+)
+
+const (
+	resolverName               = "youlingserv:resolver"
+	defaultHealthCheckInterval = 10 * time.Second
+)
+
+type primaryKey struct{}
+
+// WithPrimary 返回固定走主库的 ctx，用于写后立即读（read your writes）等不能容忍复制延迟的场景
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// isPrimaryPinned 判断 ctx 是否要求走主库
+func isPrimaryPinned(ctx context.Context) bool {
+	pinned, _ := ctx.Value(primaryKey{}).(bool)
+	return pinned
+}
+
+// Resolver gorm 插件：查询语句路由到健康的只读副本，写入、事务和加锁读走主库
+// 后台定期 Ping 副本，失败的副本被摘除，恢复后自动加回
+type Resolver struct {
+	primary  gorm.ConnPool
+	replicas []*replica
+	next     uint32
+	interval time.Duration
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+var _ gorm.Plugin = (*Resolver)(nil)
+
+// replica 一个只读副本的连接池及健康状态
+type replica struct {
+	name    string
+	db      *sql.DB
+	healthy atomic.Bool
+}
+
+// newResolver 打开所有副本的连接池，启动时 Ping 失败的副本先标记为不健康，不阻塞启动
+func newResolver(cfg *Config) (*Resolver, error) {
+	r := &Resolver{
+		interval: cfg.HealthCheckInterval,
+		stop:     make(chan struct{}),
+	}
+	if r.interval <= 0 {
+		r.interval = defaultHealthCheckInterval
+	}
+
+	for i, rc := range cfg.Replicas {
+		replicaCfg := cfg.replicaConfig(rc)
+		_, sqlDB, err := openPool(replicaCfg)
+		if err != nil {
+			_ = r.Close()
+			return nil, fmt.Errorf("replica %d: %w", i, err)
+		}
+		rep := &replica{name: fmt.Sprintf("replica-%d(%s:%d)", i, replicaCfg.Host, replicaCfg.Port), db: sqlDB}
+		r.replicas = append(r.replicas, rep)
+		if err := rep.ping(); err != nil {
+			log.GetLogger().Warn("Database unavailable at startup, kept out of read pool", zap.String("replica", rep.name), zap.Error(err))
+			continue
+		}
+		rep.healthy.Store(true)
+	}
+	return r, nil
+}
+
+// Name 实现 gorm.Plugin
+func (r *Resolver) Name() string {
+	return resolverName
+}
+
+// Initialize 实现 gorm.Plugin，注册路由回调并启动健康检查
+func (r *Resolver) Initialize(db *gorm.DB) error {
+	r.primary = db.Config.ConnPool
+
+	// 写操作（Create/Update/Delete/Exec）默认就在主库上，只需处理读操作
+	if err := db.Callback().Query().Before("*").Register(resolverName, r.route); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("*").Register(resolverName, r.route); err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go r.healthCheck()
+	return nil
+}
+
+// route 为读语句选择连接池
+func (r *Resolver) route(db *gorm.DB) {
+	stmt := db.Statement
+	// 事务中的 ConnPool 为 *sql.Tx，必须留在同一连接上
+	if stmt.ConnPool != r.primary {
+		return
+	}
+	if isPrimaryPinned(stmt.Context) {
+		return
+	}
+	// SELECT ... FOR UPDATE / FOR SHARE 需要在主库上加锁
+	if _, ok := stmt.Clauses["FOR"]; ok {
+		return
+	}
+	if rep := r.pick(); rep != nil {
+		stmt.ConnPool = rep.db
+	}
+}
+
+// pick 轮询选取健康副本，全部不可用时返回 nil（回退到主库）
+func (r *Resolver) pick() *replica {
+	n := len(r.replicas)
+	start := int(atomic.AddUint32(&r.next, 1))
+	for i := 0; i < n; i++ {
+		if rep := r.replicas[(start+i)%n]; rep.healthy.Load() {
+			return rep
+		}
+	}
+	return nil
+}
+
+func (r *Resolver) healthCheck() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			for _, rep := range r.replicas {
+				r.check(rep)
+			}
+		}
+	}
+}
+
+// check Ping 副本并在状态变化时记录日志
+func (r *Resolver) check(rep *replica) {
+	err := rep.ping()
+	healthy := err == nil
+	if rep.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		log.GetLogger().Info("Database is healthy, added back to read pool", zap.String("replica", rep.name))
+	} else {
+		log.GetLogger().Warn("Database ejected from read pool", zap.String("replica", rep.name), zap.Error(err))
+	}
+}
+
+func (rep *replica) ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	return rep.db.PingContext(ctx)
+}
+
+// Close 停止健康检查并关闭所有副本连接池，可重复调用
+func (r *Resolver) Close() error {
+	var errs []error
+	r.once.Do(func() {
+		close(r.stop)
+		r.wg.Wait()
+		for _, rep := range r.replicas {
+			if err := rep.db.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", rep.name, err))
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type resolverItem struct {
+	ID   int64
+	Name string
+}
+
+// openSQLiteFile 打开一个文件库并写入一行数据，用行内容区分主库与副本
+func openSQLiteFile(t *testing.T, path, name string) {
+	db, err := Open(&Config{Driver: DriverSQLite, Database: path})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&resolverItem{}))
+	require.NoError(t, db.Create(&resolverItem{Name: name}).Error)
+	require.NoError(t, Close(db))
+}
+
+func TestResolver_Routing(t *testing.T) {
+	dir := t.TempDir()
+	primaryPath, replicaPath := filepath.Join(dir, "primary.db"), filepath.Join(dir, "replica.db")
+	openSQLiteFile(t, primaryPath, "primary")
+	openSQLiteFile(t, replicaPath, "replica")
+
+	// SQLite 副本沿用主库文件路径，测试中替换为独立文件的连接池以区分路由
+	db, err := Open(&Config{Driver: DriverSQLite, Database: primaryPath, Replicas: []Replica{{}}})
+	require.NoError(t, err)
+	defer Close(db)
+	resolver := db.Config.Plugins[resolverName].(*Resolver)
+	replicaDB, err := Open(&Config{Driver: DriverSQLite, Database: replicaPath})
+	require.NoError(t, err)
+	defer Close(replicaDB)
+	_ = resolver.replicas[0].db.Close()
+	resolver.replicas[0].db, _ = replicaDB.DB()
+
+	ctx := context.Background()
+	name := func(db *gorm.DB) string {
+		var item resolverItem
+		require.NoError(t, db.First(&item).Error)
+		return item.Name
+	}
+
+	assert.Equal(t, "replica", name(db.WithContext(ctx)), "reads go to replica")
+	assert.Equal(t, "primary", name(db.WithContext(WithPrimary(ctx))), "pinned reads go to primary")
+	assert.Equal(t, "primary", name(db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"})), "locking reads go to primary")
+
+	require.NoError(t, db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		assert.Equal(t, "primary", name(tx), "reads in transaction go to primary")
+		return nil
+	}))
+
+	// 写入始终落在主库
+	require.NoError(t, db.WithContext(ctx).Create(&resolverItem{Name: "written"}).Error)
+	var count int64
+	require.NoError(t, db.WithContext(WithPrimary(ctx)).Model(&resolverItem{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	// 副本不可用时被摘除，读回退到主库
+	require.NoError(t, Close(replicaDB))
+	resolver.check(resolver.replicas[0])
+	assert.False(t, resolver.replicas[0].healthy.Load())
+	assert.Equal(t, "primary", name(db.WithContext(ctx)))
+}
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// sqliteDSN 构建 SQLite DSN，开启外键约束并设置忙等待，避免并发写入时立即返回 SQLITE_BUSY
+// 使用纯 Go 实现的驱动，无需 CGO，适合本地开发和 CI 中的 DAL 集成测试
+func sqliteDSN(cfg *Config) string {
+	path := cfg.Database
+	if path == "" {
+		path = ":memory:"
+	}
+
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+}
+
+func sqliteDialector(cfg *Config) gorm.Dialector {
+	return sqlite.Open(sqliteDSN(cfg))
+}
+
+func isSQLiteMemory(path string) bool {
+	return path == "" || strings.HasPrefix(path, ":memory:") || strings.Contains(path, "mode=memory")
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youlingserv/pkg/log"
+)
+
+const (
+	defaultTxRetries = 3
+	txRetryBackoff   = 10 * time.Millisecond
+)
+
+type txKey struct{}
+
+// TxManager 在 biz 层组合多个 DAL 调用为一个事务
+type TxManager interface {
+	// WithinTx 在事务中执行 fn，事务通过 fn 收到的 ctx 传递给 DAL
+	// fn 返回错误或 panic 时回滚；ctx 中已有事务时以保存点嵌套，只回滚内层
+	// 最外层事务遇到死锁或序列化失败时整体重试，fn 必须可重复执行
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txManager struct {
+	db      *gorm.DB
+	retries int
+}
+
+var _ TxManager = (*txManager)(nil)
+
+// NewTxManager 创建事务管理器，cfg.TxRetries 为死锁重试次数
+func NewTxManager(db *gorm.DB, cfg *Config) TxManager {
+	retries := cfg.TxRetries
+	if retries < 0 {
+		retries = 0
+	} else if retries == 0 {
+		retries = defaultTxRetries
+	}
+	return &txManager{
+		db:      db,
+		retries: retries,
+	}
+}
+
+// txState ctx 中保存的事务连接，以及提交后要执行的回调
+type txState struct {
+	db          *gorm.DB
+	afterCommit []func()
+}
+
+func (m *txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if parent, ok := ctx.Value(txKey{}).(*txState); ok {
+		// gorm 对已在事务中的连接调用 Transaction 时使用 SAVEPOINT
+		child := &txState{}
+		err := parent.db.WithContext(ctx).Transaction(func(sp *gorm.DB) error {
+			child.db = sp
+			return fn(context.WithValue(ctx, txKey{}, child))
+		})
+		if err == nil {
+			// 回滚到保存点时丢弃内层注册的回调
+			parent.afterCommit = append(parent.afterCommit, child.afterCommit...)
+		}
+		return err
+	}
+
+	backoff := txRetryBackoff
+	for attempt := 0; ; attempt++ {
+		state := &txState{}
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			state.db = tx
+			return fn(context.WithValue(ctx, txKey{}, state))
+		})
+		if err == nil {
+			for _, hook := range state.afterCommit {
+				hook()
+			}
+			return nil
+		}
+		if attempt >= m.retries || !IsRetryable(err) {
+			return err
+		}
+
+		// 加入抖动，避免冲突的事务同时重试再次冲突
+		wait := backoff/2 + rand.N(backoff)
+		log.FromContext(ctx).Warn("Transaction conflict, retrying",
+			zap.Int("attempt", attempt+1), zap.Int("max_attempts", m.retries+1), zap.Duration("wait", wait), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// Conn 返回绑定 ctx 的连接：ctx 中有事务时返回事务连接，否则返回 db
+// DAL 统一通过 Conn 访问数据库，即可透明地加入 biz 层开启的事务
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.db.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// InTx 判断 ctx 中是否有 WithinTx 开启的事务
+func InTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*txState)
+	return ok
+}
+
+// AfterCommit 注册在最外层事务提交成功后执行的回调，事务回滚时不执行
+// ctx 中没有事务时立即执行
+func AfterCommit(ctx context.Context, fn func()) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+	fn()
+}
+
+// IsRetryable 判断错误是否为可通过重试整个事务解决的死锁或序列化失败
+func IsRetryable(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		// 1213 ER_LOCK_DEADLOCK
+		return mysqlErr.Number == 1213
+	}
+
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		// 40001 serialization_failure，40P01 deadlock_detected
+		state := pgErr.SQLState()
+		return state == "40001" || state == "40P01"
+	}
+
+	var sqliteErr interface{ Code() int }
+	if errors.As(err, &sqliteErr) {
+		// 扩展错误码的低 8 位为主错误码：5 SQLITE_BUSY，6 SQLITE_LOCKED
+		code := sqliteErr.Code() & 0xff
+		return code == 5 || code == 6
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type txItem struct {
+	ID   int64
+	Name string
+}
+
+func newTxTestDB(t *testing.T) *gorm.DB {
+	db, err := Open(&Config{Driver: DriverSQLite, Database: ":memory:"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = Close(db) })
+	require.NoError(t, db.AutoMigrate(&txItem{}))
+	return db
+}
+
+func countTxItems(t *testing.T, db *gorm.DB) int64 {
+	var n int64
+	require.NoError(t, db.Model(&txItem{}).Count(&n).Error)
+	return n
+}
+
+func TestTxManager_CommitAndRollback(t *testing.T) {
+	db := newTxTestDB(t)
+	tm := NewTxManager(db, &Config{})
+	ctx := context.Background()
+
+	require.NoError(t, tm.WithinTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, Conn(ctx, db).Create(&txItem{Name: "a"}).Error)
+		return Conn(ctx, db).Create(&txItem{Name: "b"}).Error
+	}))
+	assert.Equal(t, int64(2), countTxItems(t, db))
+
+	err := tm.WithinTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, Conn(ctx, db).Create(&txItem{Name: "c"}).Error)
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, int64(2), countTxItems(t, db))
+}
+
+func TestTxManager_NestedSavepoint(t *testing.T) {
+	db := newTxTestDB(t)
+	tm := NewTxManager(db, &Config{})
+
+	require.NoError(t, tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		require.NoError(t, Conn(ctx, db).Create(&txItem{Name: "outer"}).Error)
+
+		// 内层失败只回滚到保存点
+		err := tm.WithinTx(ctx, func(ctx context.Context) error {
+			require.NoError(t, Conn(ctx, db).Create(&txItem{Name: "inner"}).Error)
+			return assert.AnError
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+		return nil
+	}))
+
+	var names []string
+	require.NoError(t, db.Model(&txItem{}).Pluck("name", &names).Error)
+	assert.Equal(t, []string{"outer"}, names)
+}
+
+func TestTxManager_RetryOnDeadlock(t *testing.T) {
+	db := newTxTestDB(t)
+	tm := NewTxManager(db, &Config{TxRetries: 2})
+
+	attempts := 0
+	err := tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		require.NoError(t, Conn(ctx, db).Create(&txItem{Name: fmt.Sprint(attempts)}).Error)
+		if attempts < 3 {
+			return &mysqldriver.MySQLError{Number: 1213, Message: "Deadlock found"}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, int64(1), countTxItems(t, db), "failed attempts are rolled back")
+
+	// 非冲突错误不重试
+	attempts = 0
+	err = tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return errors.New("boom")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestTxManager_AfterCommit(t *testing.T) {
+	db := newTxTestDB(t)
+	tm := NewTxManager(db, &Config{})
+
+	var fired []string
+	require.NoError(t, tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		AfterCommit(ctx, func() { fired = append(fired, "outer") })
+		_ = tm.WithinTx(ctx, func(ctx context.Context) error {
+			AfterCommit(ctx, func() { fired = append(fired, "rolled back") })
+			return assert.AnError
+		})
+		_ = tm.WithinTx(ctx, func(ctx context.Context) error {
+			AfterCommit(ctx, func() { fired = append(fired, "inner") })
+			return nil
+		})
+		assert.Empty(t, fired, "hooks wait for the outermost commit")
+		return nil
+	}))
+	assert.Equal(t, []string{"outer", "inner"}, fired)
+
+	fired = nil
+	_ = tm.WithinTx(context.Background(), func(ctx context.Context) error {
+		AfterCommit(ctx, func() { fired = append(fired, "never") })
+		return assert.AnError
+	})
+	assert.Empty(t, fired)
+
+	AfterCommit(context.Background(), func() { fired = append(fired, "now") })
+	assert.Equal(t, []string{"now"}, fired)
+}
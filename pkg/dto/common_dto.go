@@ -1,9 +1,16 @@
 package dto
 
+import (
+	"context"
+
+	"youlingserv/pkg/requestid"
+)
+
 type CommonDTO struct {
-	Code int         `json:"code"`
-	Msg  string      `json:"msg"`
-	Data interface{} `json:"data,omitempty"`
+	Code      int         `json:"code"`
+	Msg       string      `json:"msg"`
+	Data      interface{} `json:"data,omitempty"`
+	RequestID string      `json:"request_id,omitempty"` // 错误响应携带，便于按请求 ID 定位日志
 }
 
 func SuccessResponse(data interface{}) *CommonDTO {
@@ -14,9 +21,11 @@ func SuccessResponse(data interface{}) *CommonDTO {
 	}
 }
 
-func ErrorResponse(code int, msg string) *CommonDTO {
+// ErrorResponse 错误响应，带上 ctx 中的请求 ID
+func ErrorResponse(ctx context.Context, code int, msg string) *CommonDTO {
 	return &CommonDTO{
-		Code: code,
-		Msg:  msg,
+		Code:      code,
+		Msg:       msg,
+		RequestID: requestid.FromContext(ctx),
 	}
 }
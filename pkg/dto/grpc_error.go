@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"youlingserv/gen/go/common"
+	"youlingserv/pkg/log"
+)
+
+// GRPCErrorResponse 将 gRPC 错误转换为 HTTP 状态码和统一错误响应
+// 不带业务错误码（common.ErrorDetail）的 Unknown / Internal / Unavailable / DeadlineExceeded 多来自连接层，
+// 消息中可能含下游地址与 TLS 错误，只返回通用描述，原始消息记录到日志
+func GRPCErrorResponse(ctx context.Context, err error) (int, *CommonDTO) {
+	st := status.Convert(err)
+	httpCode := HTTPStatusFromCode(st.Code())
+	msg := st.Message()
+	if generic, ok := genericMessages[st.Code()]; ok && !hasErrorDetail(st) {
+		log.FromContext(ctx).Error("Upstream call failed", zap.Stringer("code", st.Code()), zap.String("error", st.Message()))
+		msg = generic
+	}
+	return httpCode, ErrorResponse(ctx, httpCode, msg)
+}
+
+// genericMessages 不直接返回原始消息的状态码及其对外描述
+var genericMessages = map[codes.Code]string{
+	codes.Unknown:          "internal error",
+	codes.Internal:         "internal error",
+	codes.Unavailable:      "service unavailable",
+	codes.DeadlineExceeded: "upstream timeout",
+}
+
+// hasErrorDetail 判断 status 是否由 errcode 生成，其消息面向调用方，可以原样返回
+func hasErrorDetail(st *status.Status) bool {
+	for _, d := range st.Details() {
+		if _, ok := d.(*common.ErrorDetail); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPStatusFromCode gRPC 状态码 → HTTP 状态码
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return 200
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return 400
+	case codes.Unauthenticated:
+		return 401
+	case codes.PermissionDenied:
+		return 403
+	case codes.NotFound:
+		return 404
+	case codes.AlreadyExists, codes.Aborted:
+		return 409
+	case codes.ResourceExhausted:
+		return 429
+	case codes.Canceled:
+		return 499
+	case codes.Unimplemented:
+		return 501
+	case codes.Unavailable:
+		return 503
+	case codes.DeadlineExceeded:
+		return 504
+	default:
+		return 500
+	}
+}
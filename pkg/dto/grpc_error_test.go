@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"youlingserv/gen/go/common"
+)
+
+func TestGRPCErrorResponse(t *testing.T) {
+	// 连接层错误不向 HTTP 调用方暴露下游地址
+	code, resp := GRPCErrorResponse(context.Background(),
+		status.Error(codes.Unavailable, "connection error: desc = \"transport: Error while dialing: dial tcp 10.0.3.7:50051: connect: connection refused\""))
+	assert.Equal(t, 503, code)
+	assert.Equal(t, "service unavailable", resp.Msg)
+
+	// 带业务错误码的状态，消息面向调用方，原样返回
+	st, err := status.New(codes.Unavailable, "server is shutting down, resume with after_id").
+		WithDetails(&common.ErrorDetail{Code: common.ErrorCode_UNAVAILABLE, Message: "server is shutting down, resume with after_id"})
+	require.NoError(t, err)
+	code, resp = GRPCErrorResponse(context.Background(), st.Err())
+	assert.Equal(t, 503, code)
+	assert.Equal(t, "server is shutting down, resume with after_id", resp.Msg)
+
+	// 其余状态码的消息不受影响
+	code, resp = GRPCErrorResponse(context.Background(), status.Error(codes.Unauthenticated, "missing credentials"))
+	assert.Equal(t, 401, code)
+	assert.Equal(t, "missing credentials", resp.Msg)
+}
@@ -0,0 +1,74 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Pool gRPC 连接池，按轮询方式把调用分散到多条 ClientConn 上
+// Pool 实现了 grpc.ClientConnInterface，可直接传给生成的 NewXxxClient
+type Pool struct {
+	conns []*grpc.ClientConn
+	next  uint32
+}
+
+var _ grpc.ClientConnInterface = (*Pool)(nil)
+
+// NewPool 创建连接池，size <= 0 时按 1 处理
+// 未传入传输凭证时默认使用明文连接
+func NewPool(target string, size int, opts ...grpc.DialOption) (*Pool, error) {
+	if target == "" {
+		return nil, errors.New("grpc pool: target is required")
+	}
+	if size <= 0 {
+		size = 1
+	}
+
+	dialOpts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, opts...)
+
+	p := &Pool{conns: make([]*grpc.ClientConn, 0, size)}
+	for i := 0; i < size; i++ {
+		conn, err := grpc.NewClient(target, dialOpts...)
+		if err != nil {
+			_ = p.Close()
+			return nil, fmt.Errorf("grpc pool: failed to create client for %s: %w", target, err)
+		}
+		p.conns = append(p.conns, conn)
+	}
+	return p, nil
+}
+
+// pick 轮询选取一条连接
+func (p *Pool) pick() *grpc.ClientConn {
+	n := atomic.AddUint32(&p.next, 1)
+	// 在 uint32 上取模，32 位平台上 int(n) 超过 2^31 后为负数
+	return p.conns[n%uint32(len(p.conns))]
+}
+
+// Invoke 实现 grpc.ClientConnInterface
+func (p *Pool) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	return p.pick().Invoke(ctx, method, args, reply, opts...)
+}
+
+// NewStream 实现 grpc.ClientConnInterface
+func (p *Pool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return p.pick().NewStream(ctx, desc, method, opts...)
+}
+
+// Close 关闭池中所有连接
+func (p *Pool) Close() error {
+	var errs []error
+	for _, conn := range p.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
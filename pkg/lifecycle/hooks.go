@@ -0,0 +1,115 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"youlingserv/pkg/log"
+)
+
+// GRPCServerHook 监听 addr 并运行 gRPC 服务器
+// 关闭时先 GracefulStop 等待存量请求结束，超过截止时间后强制 Stop
+func GRPCServerHook(srv *grpc.Server, addr string) Hook {
+	var lis net.Listener
+	return Hook{
+		Name: "grpc server",
+		OnStart: func(ctx context.Context) error {
+			var err error
+			lis, err = net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen: %w", err)
+			}
+			log.GetLogger().Info("gRPC server listening", zap.Stringer("addr", lis.Addr()))
+			return nil
+		},
+		Serve: func() error {
+			return srv.Serve(lis)
+		},
+		OnStop: func(ctx context.Context) error {
+			done := make(chan struct{})
+			go func() {
+				srv.GracefulStop()
+				close(done)
+			}()
+
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				srv.Stop()
+				return fmt.Errorf("graceful stop timed out, forced: %w", ctx.Err())
+			}
+		},
+	}
+}
+
+// HertzHook 运行 Hertz 服务器，关闭时停止接收新连接并等待存量请求结束
+func HertzHook(h *server.Hertz) Hook {
+	return Hook{
+		Name:   "http server",
+		Serve:  h.Run,
+		OnStop: h.Shutdown,
+	}
+}
+
+// HTTPServerHook 监听 srv.Addr 并运行标准库 HTTP 服务器（如 /metrics），关闭时等待存量请求结束
+func HTTPServerHook(name string, srv *http.Server) Hook {
+	var lis net.Listener
+	return Hook{
+		Name: name,
+		OnStart: func(ctx context.Context) error {
+			var err error
+			lis, err = net.Listen("tcp", srv.Addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen: %w", err)
+			}
+			log.GetLogger().Info("Server listening", zap.String("server", name), zap.Stringer("addr", lis.Addr()))
+			return nil
+		},
+		Serve: func() error {
+			if err := srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		OnStop: srv.Shutdown,
+	}
+}
+
+// CleanupHook 在关闭阶段执行 Wire 生成的 cleanup 函数（如关闭数据库连接池、下游连接）
+func CleanupHook(name string, cleanup func()) Hook {
+	return Hook{
+		Name: name,
+		OnStop: func(ctx context.Context) error {
+			cleanup()
+			return nil
+		},
+	}
+}
+
+// TracingHook 关闭阶段导出缓冲中剩余的 span，受关闭截止时间约束
+func TracingHook(shutdown func(context.Context) error) Hook {
+	return Hook{
+		Name:   "tracer provider",
+		OnStop: shutdown,
+	}
+}
+
+// LoggerHook 关闭阶段最后刷新日志缓冲
+// 向 stdout/stderr Sync 在部分平台上会返回 EINVAL，这里忽略错误
+func LoggerHook() Hook {
+	return Hook{
+		Name: "logger",
+		OnStop: func(ctx context.Context) error {
+			_ = log.GetLogger().Sync()
+			return nil
+		},
+	}
+}
@@ -0,0 +1,107 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"youlingserv/pkg/log"
+)
+
+// Hook 生命周期钩子
+// OnStart 按注册顺序执行，必须是非阻塞的；Serve 为可选的阻塞任务（如 grpc.Server.Serve），
+// 在 OnStart 成功后于后台运行；OnStop 按注册的逆序执行
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	Serve   func() error
+	OnStop  func(ctx context.Context) error
+}
+
+// Manager 管理进程内各组件的启动与优雅关闭
+type Manager struct {
+	hooks        []Hook
+	drainTimeout time.Duration
+}
+
+// NewManager 创建生命周期管理器，drainTimeout 为所有 OnStop 共享的总时限
+func NewManager(drainTimeout time.Duration) *Manager {
+	return &Manager{
+		drainTimeout: drainTimeout,
+	}
+}
+
+// Append 注册钩子，先注册的先启动、后关闭
+func (m *Manager) Append(hooks ...Hook) {
+	m.hooks = append(m.hooks, hooks...)
+}
+
+// Run 依次启动所有钩子，并阻塞直到收到 SIGINT/SIGTERM、ctx 结束或某个 Serve 退出，
+// 随后在 drainTimeout 内逆序执行 OnStop
+func (m *Manager) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var runErr error
+	serveErrCh := make(chan error, len(m.hooks))
+	started := 0
+	for _, h := range m.hooks {
+		if h.OnStart != nil {
+			if err := h.OnStart(ctx); err != nil {
+				runErr = fmt.Errorf("lifecycle: failed to start %s: %w", h.Name, err)
+				break
+			}
+		}
+		started++
+
+		if h.Serve != nil {
+			go func(name string, serve func() error) {
+				err := serve()
+				if err == nil {
+					err = errors.New("exited")
+				}
+				serveErrCh <- fmt.Errorf("lifecycle: %s stopped serving: %w", name, err)
+			}(h.Name, h.Serve)
+		}
+	}
+
+	if runErr == nil {
+		select {
+		case <-ctx.Done():
+			log.GetLogger().Info("Shutdown signal received, draining...")
+		case runErr = <-serveErrCh:
+			log.GetLogger().Error(runErr.Error())
+		}
+	}
+
+	return errors.Join(runErr, m.stop(m.hooks[:started]))
+}
+
+// stop 逆序执行 OnStop，所有钩子共享同一个截止时间
+func (m *Manager) stop(hooks []Hook) error {
+	ctx := context.Background()
+	if m.drainTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.drainTimeout)
+		defer cancel()
+	}
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+		if h.OnStop == nil {
+			continue
+		}
+		if err := h.OnStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("lifecycle: failed to stop %s: %w", h.Name, err))
+			continue
+		}
+		log.GetLogger().Info("Stopped", zap.String("hook", h.Name))
+	}
+	return errors.Join(errs...)
+}
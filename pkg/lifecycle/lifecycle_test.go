@@ -0,0 +1,91 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"youlingserv/pkg/lifecycle"
+)
+
+func recordingHook(name string, events *[]string) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: name,
+		OnStart: func(ctx context.Context) error {
+			*events = append(*events, "start "+name)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			*events = append(*events, "stop "+name)
+			return nil
+		},
+	}
+}
+
+func TestManager_StopsInReverseOrder(t *testing.T) {
+	var events []string
+	m := lifecycle.NewManager(time.Second)
+	m.Append(recordingHook("a", &events), recordingHook("b", &events))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.NoError(t, m.Run(ctx))
+	assert.Equal(t, []string{"start a", "start b", "stop b", "stop a"}, events)
+}
+
+func TestManager_ServeErrorTriggersShutdown(t *testing.T) {
+	var events []string
+	serveErr := errors.New("listener closed")
+
+	m := lifecycle.NewManager(time.Second)
+	m.Append(recordingHook("a", &events), lifecycle.Hook{
+		Name:  "server",
+		Serve: func() error { return serveErr },
+	})
+
+	err := m.Run(context.Background())
+	assert.ErrorIs(t, err, serveErr)
+	assert.Equal(t, []string{"start a", "stop a"}, events)
+}
+
+func TestManager_StartFailureStopsStartedHooks(t *testing.T) {
+	var events []string
+	startErr := errors.New("bind: address already in use")
+
+	m := lifecycle.NewManager(time.Second)
+	m.Append(recordingHook("a", &events), lifecycle.Hook{
+		Name:    "server",
+		OnStart: func(ctx context.Context) error { return startErr },
+		OnStop: func(ctx context.Context) error {
+			events = append(events, "stop server")
+			return nil
+		},
+	}, recordingHook("c", &events))
+
+	err := m.Run(context.Background())
+	assert.ErrorIs(t, err, startErr)
+	assert.Equal(t, []string{"start a", "stop a"}, events)
+}
+
+func TestManager_StopRespectsDrainTimeout(t *testing.T) {
+	m := lifecycle.NewManager(20 * time.Millisecond)
+	m.Append(lifecycle.Hook{
+		Name: "slow",
+		OnStop: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err := m.Run(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
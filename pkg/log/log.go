@@ -1,36 +1,239 @@
 package log
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// 日志格式
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// 日志输出位置
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
 )
 
 type Logger struct {
 	*zap.Logger
 }
 
-var logger *Logger
-var once sync.Once
+// Options 日志配置，零值字段使用默认值（info 级别、JSON、本地时区、stderr、不采样）
+type Options struct {
+	Level    string // debug / info / warn / error
+	Format   string // json / console
+	TimeZone string // IANA 时区名，如 Asia/Shanghai
+	Output   string // stdout / stderr / file
+	File     FileOptions
+	Sampling SamplingOptions
+}
+
+// FileOptions 输出到文件时的路径与滚动策略
+type FileOptions struct {
+	Path       string
+	MaxSizeMB  int  // 单个文件的大小上限，超过后滚动
+	MaxAgeDays int  // 旧文件的保留天数，0 不按时间清理
+	MaxBackups int  // 旧文件的保留个数，0 不按个数清理
+	Compress   bool // 是否 gzip 压缩旧文件
+}
+
+// SamplingOptions 每秒内同级别同消息的日志，前 Initial 条全部输出，之后每 Thereafter 条输出一条
+// Initial 为 0 时不采样
+type SamplingOptions struct {
+	Initial    int
+	Thereafter int
+}
+
+var (
+	logger  atomic.Pointer[Logger]
+	closer  io.Closer // 当前文件输出，替换 logger 时关闭
+	initMu  sync.Mutex
+	level   = zap.NewAtomicLevelAt(zap.InfoLevel)
+	started sync.Once
+)
 
+// GetLogger 获取当前 logger；Init 之前使用默认配置
+// 输出的日志不带请求与链路字段，只用于启动、配置热加载、访问日志刷写等与具体请求无关的场景，处理请求时使用 FromContext
 func GetLogger() *Logger {
-	once.Do(func() {
-		cfg := zap.NewProductionConfig()
-		cfg.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
-
-		cfg.EncoderConfig.TimeKey = "time"
-		cfg.EncoderConfig.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
-			// 转换为 UTC+8 时区
-			loc, _ := time.LoadLocation("Asia/Shanghai")
-			enc.AppendString(t.In(loc).Format("2006-01-02 15:04:05.000"))
-		}
-		l, err := cfg.Build()
-		if err != nil {
-			panic(err)
+	started.Do(func() {
+		if logger.Load() == nil {
+			if err := Init(Options{}); err != nil {
+				panic(err)
+			}
 		}
-		logger = &Logger{Logger: l}
 	})
-	return logger
+	return logger.Load()
+}
+
+// Init 按配置重新创建 logger 并替换当前 logger，之后 GetLogger / FromContext 返回新 logger
+// 级别保存在共享的 zap.AtomicLevel 中，SetLevel 对新旧 logger 都立即生效
+func Init(opts Options) error {
+	lvl := zap.InfoLevel
+	if opts.Level != "" {
+		var err error
+		if lvl, err = zapcore.ParseLevel(opts.Level); err != nil {
+			return fmt.Errorf("log: %w", err)
+		}
+	}
+	encoder, err := newEncoder(opts)
+	if err != nil {
+		return err
+	}
+	out, c, err := newWriteSyncer(opts)
+	if err != nil {
+		return err
+	}
+
+	core := zapcore.NewCore(encoder, out, level)
+	if opts.Sampling.Initial > 0 {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, opts.Sampling.Initial, opts.Sampling.Thereafter)
+	}
+	l := &Logger{Logger: zap.New(core,
+		zap.AddCaller(),
+		zap.AddStacktrace(zapcore.ErrorLevel),
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+	)}
+
+	initMu.Lock()
+	defer initMu.Unlock()
+	level.SetLevel(lvl)
+	if old := logger.Swap(l); old != nil {
+		_ = old.Sync()
+	}
+	if closer != nil {
+		_ = closer.Close()
+	}
+	closer = c
+	return nil
+}
+
+// SetLevel 运行时调整日志级别
+func SetLevel(l string) error {
+	lvl, err := zapcore.ParseLevel(l)
+	if err != nil {
+		return fmt.Errorf("log: %w", err)
+	}
+	level.SetLevel(lvl)
+	return nil
+}
+
+// Level 当前日志级别
+func Level() string {
+	return level.Level().String()
+}
+
+func newEncoder(opts Options) (zapcore.Encoder, error) {
+	loc := time.Local
+	if opts.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(opts.TimeZone); err != nil {
+			return nil, fmt.Errorf("log: %w", err)
+		}
+	}
+
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.TimeKey = "time"
+	cfg.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendString(t.In(loc).Format("2006-01-02 15:04:05.000"))
+	}
+	switch opts.Format {
+	case "", FormatJSON:
+		return zapcore.NewJSONEncoder(cfg), nil
+	case FormatConsole:
+		cfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		return zapcore.NewConsoleEncoder(cfg), nil
+	default:
+		return nil, fmt.Errorf("log: unknown format %q", opts.Format)
+	}
+}
+
+// newWriteSyncer 返回日志输出位置，输出到文件时另外返回用于关闭文件的 io.Closer
+func newWriteSyncer(opts Options) (zapcore.WriteSyncer, io.Closer, error) {
+	switch opts.Output {
+	case "", OutputStderr:
+		return zapcore.Lock(os.Stderr), nil, nil
+	case OutputStdout:
+		return zapcore.Lock(os.Stdout), nil, nil
+	case OutputFile:
+		if opts.File.Path == "" {
+			return nil, nil, errors.New("log: file path is required for file output")
+		}
+		w := &lumberjack.Logger{
+			Filename:   opts.File.Path,
+			MaxSize:    opts.File.MaxSizeMB,
+			MaxAge:     opts.File.MaxAgeDays,
+			MaxBackups: opts.File.MaxBackups,
+			Compress:   opts.File.Compress,
+			LocalTime:  true,
+		}
+		// lumberjack 自带锁，写入不经过缓冲
+		return zapcore.AddSync(w), w, nil
+	default:
+		return nil, nil, fmt.Errorf("log: unknown output %q", opts.Output)
+	}
+}
+
+// 请求级日志字段名，由 HTTP / gRPC 中间件写入 ctx
+const (
+	FieldRequestID = "request_id"
+	FieldUserID    = "user_id"
+	FieldTraceID   = "trace_id"
+	FieldSpanID    = "span_id"
+	FieldRPCMethod = "rpc_method" // gRPC 全方法名，HTTP 请求为 "GET /api/v1/..." 形式的路由
+)
+
+type fieldsKey struct{}
+
+// WithFields 返回附加了日志字段的 ctx，之后 FromContext(ctx) 输出的每行日志都带上这些字段
+// 与 ctx 中已有字段同名时后写入的生效
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	old, _ := ctx.Value(fieldsKey{}).([]zap.Field)
+	merged := make([]zap.Field, 0, len(old)+len(fields))
+	for _, f := range old {
+		if !hasKey(fields, f.Key) {
+			merged = append(merged, f)
+		}
+	}
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+func hasKey(fields []zap.Field, key string) bool {
+	for _, f := range fields {
+		if f.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// FromContext 返回附带请求级字段（request_id、user_id、rpc_method 等）与当前 span 的 trace_id / span_id 的 logger
+// ctx 中没有这些信息时返回全局 logger；只有经此取得的 logger 才带 trace_id / span_id
+func FromContext(ctx context.Context) *Logger {
+	fields, _ := ctx.Value(fieldsKey{}).([]zap.Field)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields[:len(fields):len(fields)],
+			zap.String(FieldTraceID, sc.TraceID().String()),
+			zap.String(FieldSpanID, sc.SpanID().String()),
+		)
+	}
+	if len(fields) == 0 {
+		return GetLogger()
+	}
+	return &Logger{Logger: GetLogger().With(fields...)}
 }
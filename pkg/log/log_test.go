@@ -0,0 +1,103 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+func TestInit_FileOutputAndRuntimeLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	require.NoError(t, Init(Options{
+		Level:    "info",
+		Format:   FormatConsole,
+		TimeZone: "UTC",
+		Output:   OutputFile,
+		File:     FileOptions{Path: path, MaxSizeMB: 1},
+	}))
+	t.Cleanup(func() { _ = Init(Options{}) })
+
+	GetLogger().Debug("hidden")
+	GetLogger().Info("shown")
+	require.NoError(t, SetLevel("debug"))
+	assert.Equal(t, "debug", Level())
+	GetLogger().Debug("now shown")
+	require.NoError(t, SetLevel("error"))
+	GetLogger().Warn("hidden again")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "INFO")
+	assert.Contains(t, lines[0], "shown")
+	assert.Contains(t, lines[1], "DEBUG")
+	assert.Contains(t, lines[1], "now shown")
+
+	assert.Error(t, SetLevel("verbose"))
+	assert.Error(t, Init(Options{Format: "xml"}))
+	assert.Error(t, Init(Options{Output: OutputFile}))
+	assert.Equal(t, "error", Level(), "failed Init keeps the current logger and level")
+}
+
+func TestFromContext_RequestFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	require.NoError(t, Init(Options{Output: OutputFile, File: FileOptions{Path: path}}))
+	t.Cleanup(func() { _ = Init(Options{}) })
+
+	ctx := WithFields(context.Background(), zap.String(FieldRequestID, "req-1"), zap.String(FieldUserID, "anonymous"))
+	ctx = WithFields(ctx, zap.String(FieldUserID, "alice"), zap.String(FieldRPCMethod, "/adhoc.v1.AdhocService/Hello"))
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	FromContext(ctx).Info("handled", zap.Int("count", 3))
+	FromContext(context.Background()).Info("plain")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "req-1", entry[FieldRequestID])
+	assert.Equal(t, "alice", entry[FieldUserID], "later fields replace earlier ones")
+	assert.Equal(t, "/adhoc.v1.AdhocService/Hello", entry[FieldRPCMethod])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", entry[FieldTraceID])
+	assert.Equal(t, "00f067aa0ba902b7", entry[FieldSpanID])
+	assert.Equal(t, float64(3), entry["count"])
+	assert.Equal(t, 1, strings.Count(lines[0], FieldUserID))
+	assert.NotContains(t, lines[1], FieldRequestID)
+}
+
+// 只有 FromContext 返回的 logger 带链路字段，GetLogger 用于与请求无关的后台日志
+func TestGetLogger_NoTraceFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	require.NoError(t, Init(Options{Output: OutputFile, File: FileOptions{Path: path}}))
+	t.Cleanup(func() { _ = Init(Options{}) })
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	FromContext(ctx).Info("request")
+	GetLogger().Info("background")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], FieldTraceID)
+	assert.Contains(t, lines[0], FieldSpanID)
+	assert.NotContains(t, lines[1], FieldTraceID)
+	assert.NotContains(t, lines[1], FieldSpanID)
+}
@@ -0,0 +1,354 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"youlingserv/pkg/database"
+	"youlingserv/pkg/log"
+)
+
+const (
+	// Table 迁移记录表
+	Table = "schema_migrations"
+
+	lockName    = "youlingserv_schema_migrations"
+	lockTimeout = 60 * time.Second
+)
+
+var (
+	// fileRe 迁移文件名：<version>_<name>.(up|down).sql
+	fileRe = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+	// nameRe 迁移名称中需要替换为下划线的字符
+	nameRe = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Migration 一个版本的正向与回滚 SQL
+type Migration struct {
+	Version int64
+	Name    string
+	UpSQL   string
+	DownSQL string
+}
+
+// Status 迁移的执行状态
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Load 读取 fsys 根目录下的迁移文件，按版本升序返回
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("migrate: failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		m := fileRe.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+		content, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("migrate: failed to read %s: %w", e.Name(), err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migrate: version %d is used by both %q and %q", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.UpSQL = string(content)
+		} else {
+			mig.DownSQL = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if strings.TrimSpace(mig.UpSQL) == "" {
+			return nil, fmt.Errorf("migrate: version %d (%s) has no up migration", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Create 在 dir 下生成一对以当前 UTC 时间为版本号的空迁移文件
+func Create(dir, name string, now time.Time) (string, string, error) {
+	name = strings.Trim(nameRe.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", errors.New("migrate: migration name is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", err
+	}
+
+	base := fmt.Sprintf("%s_%s", now.UTC().Format("20060102150405"), name)
+	up := filepath.Join(dir, base+".up.sql")
+	down := filepath.Join(dir, base+".down.sql")
+	if err := os.WriteFile(up, []byte("-- "+base+" up\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(down, []byte("-- "+base+" down\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	return up, down, nil
+}
+
+// Migrator 执行版本化迁移
+// 通过数据库级别的咨询锁保证多副本同时启动时只有一个实例在迁移
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New 创建迁移器，migrations 需按版本升序排列（Load 的返回值即可）
+func New(db *gorm.DB, migrations []Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}
+}
+
+// Up 按版本顺序执行未执行的迁移，steps <= 0 表示全部
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	ctx = database.WithPrimary(ctx) // 迁移记录必须读主库
+	var done []Migration
+	err := m.withLock(ctx, func() error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if steps > 0 && len(done) >= steps {
+				break
+			}
+			if err := m.apply(ctx, mig, true); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down 按版本倒序回滚已执行的迁移，steps <= 0 表示全部
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	ctx = database.WithPrimary(ctx)
+	var done []Migration
+	err := m.withLock(ctx, func() error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if steps > 0 && len(done) >= steps {
+				break
+			}
+			if err := m.apply(ctx, mig, false); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status 返回所有迁移及其执行状态
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	ctx = database.WithPrimary(ctx)
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		at, ok := applied[mig.Version]
+		result = append(result, Status{Migration: mig, Applied: ok, AppliedAt: at})
+	}
+	return result, nil
+}
+
+// apply 在事务中执行一个迁移并更新迁移记录
+// 注意：MySQL 的 DDL 会隐式提交，失败时可能需要手动清理
+func (m *Migrator) apply(ctx context.Context, mig Migration, up bool) error {
+	script, direction := mig.UpSQL, "up"
+	if !up {
+		script, direction = mig.DownSQL, "down"
+	}
+
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, stmt := range splitStatements(script) {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		if up {
+			return tx.Exec("INSERT INTO "+Table+" (version, name, applied_at) VALUES (?, ?, ?)",
+				mig.Version, mig.Name, time.Now().UTC()).Error
+		}
+		return tx.Exec("DELETE FROM "+Table+" WHERE version = ?", mig.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migrate: %d_%s %s failed: %w", mig.Version, mig.Name, direction, err)
+	}
+
+	log.FromContext(ctx).Info("Migrated", zap.Int64("version", mig.Version), zap.String("name", mig.Name), zap.String("direction", direction))
+	return nil
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	return m.db.WithContext(ctx).Exec("CREATE TABLE IF NOT EXISTS " + Table + " (" +
+		"version BIGINT NOT NULL PRIMARY KEY, " +
+		"name VARCHAR(255) NOT NULL, " +
+		"applied_at TIMESTAMP NOT NULL)").Error
+}
+
+// applied 返回已执行的版本及执行时间
+func (m *Migrator) applied(ctx context.Context) (map[int64]time.Time, error) {
+	var rows []struct {
+		Version   int64
+		AppliedAt time.Time
+	}
+	if err := m.db.WithContext(ctx).Table(Table).Select("version, applied_at").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("migrate: failed to read %s: %w", Table, err)
+	}
+
+	applied := make(map[int64]time.Time, len(rows))
+	for _, r := range rows {
+		applied[r.Version] = r.AppliedAt
+	}
+	return applied, nil
+}
+
+// withLock 获取咨询锁后执行 fn
+// MySQL 使用 GET_LOCK，PostgreSQL 使用 pg_advisory_lock；SQLite 为单机文件库，不加锁
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	if err := m.ensureTable(ctx); err != nil {
+		return err
+	}
+
+	dialect := m.db.Dialector.Name()
+	if dialect != "mysql" && dialect != "postgres" {
+		return fn()
+	}
+
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return err
+	}
+	// 会话级锁，加锁和解锁必须在同一条连接上
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate: failed to get connection for lock: %w", err)
+	}
+	defer conn.Close()
+
+	lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+
+	var unlockSQL string
+	var unlockArg any
+	switch dialect {
+	case "mysql":
+		var got sql.NullInt64
+		err = conn.QueryRowContext(lockCtx, "SELECT GET_LOCK(?, ?)", lockName, int(lockTimeout.Seconds())).Scan(&got)
+		if err == nil && (!got.Valid || got.Int64 != 1) {
+			err = context.DeadlineExceeded
+		}
+		unlockSQL, unlockArg = "SELECT RELEASE_LOCK(?)", lockName
+	case "postgres":
+		_, err = conn.ExecContext(lockCtx, "SELECT pg_advisory_lock($1)", lockKey())
+		unlockSQL, unlockArg = "SELECT pg_advisory_unlock($1)", lockKey()
+	}
+	if err != nil {
+		return fmt.Errorf("migrate: failed to acquire lock %q: %w", lockName, err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), unlockSQL, unlockArg); err != nil {
+			log.GetLogger().Error("Failed to release migration lock", zap.Error(err))
+		}
+	}()
+
+	return fn()
+}
+
+// lockKey PostgreSQL 咨询锁的 int64 键
+func lockKey() int64 {
+	h := fnv.New64a()
+	h.Write([]byte(lockName))
+	return int64(h.Sum64())
+}
+
+// splitStatements 按行尾分号切分 SQL 脚本，忽略空语句和纯注释
+func splitStatements(script string) []string {
+	var (
+		stmts []string
+		cur   strings.Builder
+	)
+	flush := func() {
+		if stmt := strings.TrimSpace(cur.String()); hasSQL(stmt) {
+			stmts = append(stmts, stmt)
+		}
+		cur.Reset()
+	}
+
+	for _, line := range strings.Split(script, "\n") {
+		cur.WriteString(line)
+		cur.WriteByte('\n')
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			flush()
+		}
+	}
+	flush()
+	return stmts
+}
+
+// hasSQL 判断语句中是否有注释以外的内容
+func hasSQL(stmt string) bool {
+	for _, line := range strings.Split(stmt, "\n") {
+		if l := strings.TrimSpace(line); l != "" && !strings.HasPrefix(l, "--") {
+			return true
+		}
+	}
+	return false
+}
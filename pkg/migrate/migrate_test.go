@@ -0,0 +1,90 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"youlingserv/pkg/database"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := database.Open(&database.Config{Driver: database.DriverSQLite, Database: ":memory:"})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		_ = sqlDB.Close()
+	})
+	return db
+}
+
+var testFS = fstest.MapFS{
+	"1_create_a.up.sql":   {Data: []byte("-- table a\nCREATE TABLE a (id INTEGER);\nCREATE INDEX idx_a_id ON a (id);\n")},
+	"1_create_a.down.sql": {Data: []byte("DROP TABLE a;")},
+	"2_create_b.up.sql":   {Data: []byte("CREATE TABLE b (id INTEGER);")},
+	"2_create_b.down.sql": {Data: []byte("DROP TABLE b;")},
+	"README.md":           {Data: []byte("ignored")},
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(testFS)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "create_a", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE b;", migrations[1].DownSQL)
+
+	_, err = Load(fstest.MapFS{"1_x.down.sql": {Data: []byte("DROP TABLE x;")}})
+	assert.ErrorContains(t, err, "no up migration")
+}
+
+func TestMigrator_UpStatusDown(t *testing.T) {
+	db := newTestDB(t)
+	migrations, err := Load(testFS)
+	require.NoError(t, err)
+	m := New(db, migrations)
+	ctx := context.Background()
+
+	done, err := m.Up(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+
+	done, err = m.Up(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	assert.True(t, db.Migrator().HasTable("b"))
+
+	done, err = m.Down(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, done, 2)
+	assert.Equal(t, int64(2), done[0].Version)
+	assert.False(t, db.Migrator().HasTable("a"))
+	assert.False(t, db.Migrator().HasTable("b"))
+}
+
+func TestMigrator_FailedMigrationRollsBack(t *testing.T) {
+	db := newTestDB(t)
+	m := New(db, []Migration{{Version: 1, Name: "broken", UpSQL: "CREATE TABLE c (id INTEGER);\nNOT SQL;"}})
+
+	_, err := m.Up(context.Background(), 0)
+	assert.ErrorContains(t, err, "1_broken up failed")
+	assert.False(t, db.Migrator().HasTable("c"))
+
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	assert.False(t, statuses[0].Applied)
+}
+
+func TestSplitStatements(t *testing.T) {
+	stmts := splitStatements("-- header\nCREATE TABLE a (\n  id INTEGER\n);\n\n-- trailing comment\n")
+	assert.Equal(t, []string{"-- header\nCREATE TABLE a (\n  id INTEGER\n);"}, stmts)
+}
@@ -1,4 +1,65 @@
 package observability
 
-func InitLogging(level string) {
+import (
+	"sync"
+
+	"go.uber.org/zap"
+
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/log"
+)
+
+var (
+	logLevelMu   sync.Mutex
+	logConfLevel string // 最近一次从配置文件应用的级别
+)
+
+// InitLogging 按配置重建全局 logger，并在配置文件热加载时应用新的 log.level
+// 只有配置文件中的级别变化时才覆盖当前级别，通过管理端点临时调整的级别不会被无关的配置修改重置
+func InitLogging(conf config.LogConfig) error {
+	if err := log.Init(logOptions(conf)); err != nil {
+		return err
+	}
+	logLevelMu.Lock()
+	logConfLevel = conf.Level
+	logLevelMu.Unlock()
+
+	config.OnChange(func(c *config.Config) {
+		applyConfigLogLevel(c.LogConf.Level)
+	})
+	return nil
+}
+
+func applyConfigLogLevel(level string) {
+	logLevelMu.Lock()
+	defer logLevelMu.Unlock()
+	if level == logConfLevel {
+		return
+	}
+	if err := log.SetLevel(level); err != nil {
+		log.GetLogger().Error("Ignoring log.level from reloaded config", zap.Error(err))
+		return
+	}
+	logConfLevel = level
+	log.GetLogger().Info("Log level changed by config reload", zap.String("level", level))
+}
+
+func logOptions(conf config.LogConfig) log.Options {
+	return log.Options{
+		Level:    conf.Level,
+		Format:   conf.Format,
+		TimeZone: conf.TimeZone,
+		Output:   conf.Output,
+		File: log.FileOptions{
+			Path:       conf.File.Path,
+			MaxSizeMB:  conf.File.MaxSizeMB,
+			MaxAgeDays: conf.File.MaxAgeDays,
+			MaxBackups: conf.File.MaxBackups,
+			Compress:   conf.File.Compress,
+		},
+		Sampling: log.SamplingOptions{
+			Initial:    conf.Sampling.Initial,
+			Thereafter: conf.Sampling.Thereafter,
+		},
+	}
 }
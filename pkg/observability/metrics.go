@@ -1,27 +1,231 @@
 package observability
 
 import (
-	"fmt"
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+
 	"youlingserv/pkg/log"
 )
 
-func RecordHTTPMetrics(method, path string, statusCode int, duration time.Duration) {
-	log.GetLogger().Info(
-		fmt.Sprintf("HTTP request: method=%s path=%s status=%d duration=%dms",
-			method, path, statusCode, duration.Milliseconds()),
+// UnmatchedRoute 未匹配到路由（404 / 405）的请求使用的 route 标签，避免原始路径撑爆标签基数
+const UnmatchedRoute = "<unmatched>"
+
+// gRPC 调用类型标签
+const (
+	grpcTypeUnary  = "unary"
+	grpcTypeStream = "stream"
+)
+
+// serverMetrics 进程内的全部指标，注册在独立的 Registry 上
+type serverMetrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	httpInFlight *prometheus.GaugeVec
+
+	grpcHandled     *prometheus.CounterVec
+	grpcDuration    *prometheus.HistogramVec
+	grpcInFlight    *prometheus.GaugeVec
+	grpcMsgSent     *prometheus.CounterVec
+	grpcMsgReceived *prometheus.CounterVec
+
+	accessLogFlushes       *prometheus.CounterVec
+	accessLogFlushDuration *prometheus.HistogramVec
+	accessLogBatchSize     prometheus.Histogram
+	accessLogDropped       *prometheus.CounterVec
+}
+
+var (
+	metrics     *serverMetrics
+	metricsOnce sync.Once
+)
+
+// InitMetrics 按配置的耗时分桶（秒）创建指标，buckets 为空时使用 prometheus.DefBuckets
+// 需在处理第一个请求前调用；未调用时首次记录指标使用默认分桶，之后的调用不再生效
+func InitMetrics(buckets []float64) {
+	metricsOnce.Do(func() {
+		metrics = newServerMetrics(buckets)
+	})
+}
+
+func getMetrics() *serverMetrics {
+	InitMetrics(nil)
+	return metrics
+}
+
+func newServerMetrics(buckets []float64) *serverMetrics {
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+	m := &serverMetrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests handled, by method, route template and status code.",
+		}, []string{"method", "route", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency in seconds, by method and route template.",
+			Buckets: buckets,
+		}, []string{"method", "route"}),
+		httpInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "HTTP requests currently being handled, by method and route template.",
+		}, []string{"method", "route"}),
+
+		grpcHandled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "gRPC calls completed on the server, by method, type and status code.",
+		}, []string{"grpc_method", "grpc_type", "grpc_code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "gRPC call latency in seconds (stream lifetime for streaming calls), by method and type.",
+			Buckets: buckets,
+		}, []string{"grpc_method", "grpc_type"}),
+		grpcInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "grpc_server_in_flight",
+			Help: "gRPC calls currently being handled, by method and type.",
+		}, []string{"grpc_method", "grpc_type"}),
+		grpcMsgSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_msg_sent_total",
+			Help: "Messages sent on gRPC server streams, by method.",
+		}, []string{"grpc_method"}),
+		grpcMsgReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_msg_received_total",
+			Help: "Messages received on gRPC server streams, by method.",
+		}, []string{"grpc_method"}),
+
+		accessLogFlushes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "access_log_flushes_total",
+			Help: "Access log batch inserts, by result.",
+		}, []string{"status"}),
+		accessLogFlushDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "access_log_flush_duration_seconds",
+			Help:    "Access log batch insert latency in seconds, by result.",
+			Buckets: buckets,
+		}, []string{"status"}),
+		accessLogBatchSize: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "access_log_flush_batch_size",
+			Help:    "Records per access log batch insert.",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+		}),
+		accessLogDropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "access_log_dropped_total",
+			Help: "Access log records dropped, by reason.",
+		}, []string{"reason"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration, m.httpInFlight,
+		m.grpcHandled, m.grpcDuration, m.grpcInFlight, m.grpcMsgSent, m.grpcMsgReceived,
+		m.accessLogFlushes, m.accessLogFlushDuration, m.accessLogBatchSize, m.accessLogDropped,
 	)
+	return m
 }
 
-func RecordGRPCMetrics(method string, err error, duration time.Duration) {
-	status := "success"
+// Registry 指标所在的 Registry，供测试或自定义 exporter 使用
+func Registry() *prometheus.Registry {
+	return getMetrics().registry
+}
+
+// NewMetricsServer 在 addr 上以 Prometheus 文本格式暴露 /metrics 的独立 HTTP 服务
+func NewMetricsServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry(), promhttp.HandlerOpts{}))
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// HTTPRequestStarted 请求开始处理，返回的函数在请求结束时调用
+func HTTPRequestStarted(method, route string) func() {
+	g := getMetrics().httpInFlight.WithLabelValues(method, route)
+	g.Inc()
+	return g.Dec
+}
+
+// RecordHTTPMetrics route 为路由模板（如 /api/v1/users/:id），未匹配时传 UnmatchedRoute
+func RecordHTTPMetrics(ctx context.Context, method, route string, statusCode int, duration time.Duration) {
+	m := getMetrics()
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(statusCode)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+
+	log.FromContext(ctx).Debug("HTTP request",
+		zap.String("method", method), zap.String("route", route), zap.Int("status", statusCode), zap.Duration("duration", duration))
+}
+
+// GRPCCallStarted 一元调用开始处理，返回的函数在调用结束时调用
+func GRPCCallStarted(method string) func() {
+	return grpcStarted(method, grpcTypeUnary)
+}
+
+// GRPCStreamStarted 流式调用开始处理，返回的函数在流结束时调用
+func GRPCStreamStarted(method string) func() {
+	return grpcStarted(method, grpcTypeStream)
+}
+
+func grpcStarted(method, grpcType string) func() {
+	g := getMetrics().grpcInFlight.WithLabelValues(method, grpcType)
+	g.Inc()
+	return g.Dec
+}
+
+// RecordGRPCMetrics 记录一元 RPC 的状态与耗时，调试日志经 ctx 带上请求与链路字段
+func RecordGRPCMetrics(ctx context.Context, method string, err error, duration time.Duration) {
+	code := status.Code(err).String()
+	m := getMetrics()
+	m.grpcHandled.WithLabelValues(method, grpcTypeUnary, code).Inc()
+	m.grpcDuration.WithLabelValues(method, grpcTypeUnary).Observe(duration.Seconds())
+
+	log.FromContext(ctx).Debug("gRPC request",
+		zap.String("method", method), zap.String("code", code), zap.Duration("duration", duration))
+}
+
+// RecordGRPCStreamMetrics 记录流式 RPC 的状态、持续时间与收发消息数
+func RecordGRPCStreamMetrics(ctx context.Context, method string, err error, duration time.Duration, sent, received int) {
+	code := status.Code(err).String()
+	m := getMetrics()
+	m.grpcHandled.WithLabelValues(method, grpcTypeStream, code).Inc()
+	m.grpcDuration.WithLabelValues(method, grpcTypeStream).Observe(duration.Seconds())
+	m.grpcMsgSent.WithLabelValues(method).Add(float64(sent))
+	m.grpcMsgReceived.WithLabelValues(method).Add(float64(received))
+
+	log.FromContext(ctx).Debug("gRPC stream",
+		zap.String("method", method), zap.String("code", code), zap.Duration("duration", duration),
+		zap.Int("sent", sent), zap.Int("received", received))
+}
+
+// RecordAccessLogFlush 记录访问日志批量写入的批大小与耗时
+func RecordAccessLogFlush(size int, err error, duration time.Duration) {
+	result := "success"
 	if err != nil {
-		status = "error"
+		result = "error"
 	}
+	m := getMetrics()
+	m.accessLogFlushes.WithLabelValues(result).Inc()
+	m.accessLogFlushDuration.WithLabelValues(result).Observe(duration.Seconds())
+	m.accessLogBatchSize.Observe(float64(size))
 
-	log.GetLogger().Info(
-		fmt.Sprintf("gRPC request: method=%s status=%s duration=%dms",
-			method, status, duration.Milliseconds()),
-	)
+	log.GetLogger().Debug("Access log flush",
+		zap.Int("size", size), zap.String("status", result), zap.Duration("duration", duration))
+}
+
+// RecordAccessLogDropped 记录被丢弃的访问日志数量，reason 为溢出策略或 flush_failed
+// 过载时每条记录都可能被丢弃，这里只计数不打日志，由写入器按刷写间隔汇总输出
+func RecordAccessLogDropped(reason string, n int) {
+	getMetrics().accessLogDropped.WithLabelValues(reason).Add(float64(n))
 }
@@ -0,0 +1,63 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetricsServer_ExposesRecordedMetrics(t *testing.T) {
+	done := HTTPRequestStarted("GET", "/api/v1/users/:id")
+	RecordHTTPMetrics(context.Background(), "GET", "/api/v1/users/:id", 200, 30*time.Millisecond)
+	RecordGRPCMetrics(context.Background(), "/adhoc.v1.AdhocService/Hello", status.Error(codes.NotFound, "missing"), time.Millisecond)
+	RecordGRPCStreamMetrics(context.Background(), "/adhoc.v1.AdhocService/WatchAccessLogs", nil, time.Second, 3, 1)
+	RecordAccessLogDropped("drop_oldest", 2)
+	RecordAccessLogFlush(5, errors.New("boom"), time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	NewMetricsServer(":0").Handler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, 200, rec.Code)
+	body, _ := io.ReadAll(rec.Body)
+	text := string(body)
+
+	for _, series := range []string{
+		`http_requests_total{code="200",method="GET",route="/api/v1/users/:id"} 1`,
+		`http_request_duration_seconds_count{method="GET",route="/api/v1/users/:id"} 1`,
+		`http_requests_in_flight{method="GET",route="/api/v1/users/:id"} 1`,
+		`grpc_server_handled_total{grpc_code="NotFound",grpc_method="/adhoc.v1.AdhocService/Hello",grpc_type="unary"} 1`,
+		`grpc_server_handled_total{grpc_code="OK",grpc_method="/adhoc.v1.AdhocService/WatchAccessLogs",grpc_type="stream"} 1`,
+		`grpc_server_msg_sent_total{grpc_method="/adhoc.v1.AdhocService/WatchAccessLogs"} 3`,
+		`access_log_dropped_total{reason="drop_oldest"} 2`,
+		`access_log_flushes_total{status="error"} 1`,
+		`access_log_flush_duration_seconds_count{status="error"} 1`,
+		`go_goroutines`,
+	} {
+		assert.Contains(t, text, series)
+	}
+
+	done()
+	assert.Equal(t, 0.0, gaugeValue(t, "http_requests_in_flight"))
+}
+
+// gaugeValue 读取只有一个序列的 gauge 的当前值
+func gaugeValue(t *testing.T, name string) float64 {
+	t.Helper()
+	families, err := Registry().Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() == name {
+			require.Len(t, f.GetMetric(), 1)
+			return f.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	t.Fatalf("metric %s not found", name)
+	return 0
+}
@@ -1,5 +1,111 @@
 package observability
 
-func InitTracing(serviceName string) error {
-	return nil
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"youlingserv/pkg/config"
+)
+
+// 链路追踪的导出方式
+const (
+	TraceExporterNone   = "none"   // 只生成 trace / span ID（用于日志关联与向下游传递），不导出
+	TraceExporterOTLP   = "otlp"   // 通过 OTLP gRPC 发送到收集器
+	TraceExporterStdout = "stdout" // 打印到标准输出，便于本地调试
+	TraceExporterFile   = "file"   // 写入本地文件，离线分析
+)
+
+const tracerName = "youlingserv"
+
+// InitTracing 创建全局 TracerProvider 并启用 W3C traceparent / baggage 传播
+// 返回的 shutdown 在进程退出时调用，导出缓冲中剩余的 span
+func InitTracing(serviceName string, conf config.TracingConfig) (func(context.Context) error, error) {
+	if conf.SampleRatio < 0 || conf.SampleRatio > 1 {
+		return nil, fmt.Errorf("tracing: sample_ratio must be between 0 and 1, got %v", conf.SampleRatio)
+	}
+	exporter, closeExporter, err := newTraceExporter(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+		// 上游已有采样决定时沿用，保证一条链路要么完整要么不采
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SampleRatio))),
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	tp := sdktrace.NewTracerProvider(opts...)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	shutdown := func(ctx context.Context) error {
+		return errors.Join(tp.Shutdown(ctx), closeExporter())
+	}
+	return shutdown, nil
+}
+
+// newTraceExporter 按配置创建导出器，none 时返回 nil
+func newTraceExporter(conf config.TracingConfig) (sdktrace.SpanExporter, func() error, error) {
+	noClose := func() error { return nil }
+	switch conf.Exporter {
+	case "", TraceExporterNone:
+		return nil, noClose, nil
+	case TraceExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(conf.Endpoint)}
+		if conf.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		// 连接在后台建立，收集器暂不可用不影响启动
+		exporter, err := otlptracegrpc.New(context.Background(), opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tracing: create otlp exporter: %w", err)
+		}
+		return exporter, noClose, nil
+	case TraceExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, nil, fmt.Errorf("tracing: create stdout exporter: %w", err)
+		}
+		return exporter, noClose, nil
+	case TraceExporterFile:
+		if conf.FilePath == "" {
+			return nil, nil, errors.New("tracing: file_path is required for the file exporter")
+		}
+		f, err := os.OpenFile(conf.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tracing: %w", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, nil, fmt.Errorf("tracing: create file exporter: %w", err)
+		}
+		return exporter, f.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("tracing: unknown exporter %q", conf.Exporter)
+	}
+}
+
+// Tracer 中间件与业务代码创建 span 使用的 Tracer，InitTracing 之前调用得到的是 no-op 实现
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Propagator 跨进程传递上下文使用的传播器
+func Propagator() propagation.TextMapPropagator {
+	return otel.GetTextMapPropagator()
 }
@@ -0,0 +1,127 @@
+// Package pubsub 进程内发布订阅，发布不阻塞，消费过慢的订阅者被剔除
+package pubsub
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrSlowConsumer 订阅者缓冲区已满，被剔除
+	ErrSlowConsumer = errors.New("pubsub: slow consumer evicted")
+	// ErrBrokerClosed Broker 已关闭
+	ErrBrokerClosed = errors.New("pubsub: broker closed")
+)
+
+const defaultBufferSize = 256
+
+// Broker 把消息广播给所有订阅者
+// 每个订阅者有独立的有界缓冲区，Publish 从不阻塞：缓冲区满的订阅者直接被剔除，
+// 由订阅方根据 Err 决定是否重新订阅并补齐缺失的数据
+type Broker[T any] struct {
+	mu         sync.Mutex
+	subs       map[*Subscription[T]]struct{}
+	bufferSize int
+	closed     bool
+}
+
+// Subscription 一个订阅，消息从 C 读取，C 关闭后通过 Err 查看原因
+type Subscription[T any] struct {
+	ch     chan T
+	broker *Broker[T]
+	err    error // 受 broker.mu 保护
+}
+
+// NewBroker 创建 Broker，bufferSize 为每个订阅者的缓冲区大小，<= 0 时使用默认值
+func NewBroker[T any](bufferSize int) *Broker[T] {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	return &Broker[T]{
+		subs:       make(map[*Subscription[T]]struct{}),
+		bufferSize: bufferSize,
+	}
+}
+
+// Subscribe 新建订阅，Broker 已关闭时返回的订阅 C 已关闭
+func (b *Broker[T]) Subscribe() *Subscription[T] {
+	s := &Subscription[T]{
+		ch:     make(chan T, b.bufferSize),
+		broker: b,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		s.err = ErrBrokerClosed
+		close(s.ch)
+		return s
+	}
+	b.subs[s] = struct{}{}
+	return s
+}
+
+// Publish 按顺序把消息投递给所有订阅者
+func (b *Broker[T]) Publish(msgs ...T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for s := range b.subs {
+		for _, msg := range msgs {
+			select {
+			case s.ch <- msg:
+				continue
+			default:
+			}
+			b.remove(s, ErrSlowConsumer)
+			break
+		}
+	}
+}
+
+// Len 当前订阅者数量
+func (b *Broker[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
+
+// Close 关闭所有订阅，之后的 Publish 不再投递，可重复调用
+func (b *Broker[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for s := range b.subs {
+		b.remove(s, ErrBrokerClosed)
+	}
+}
+
+// remove 调用方持有 b.mu
+func (b *Broker[T]) remove(s *Subscription[T], err error) {
+	if _, ok := b.subs[s]; !ok {
+		return
+	}
+	delete(b.subs, s)
+	s.err = err
+	close(s.ch)
+}
+
+// C 消息通道，订阅结束时关闭
+func (s *Subscription[T]) C() <-chan T {
+	return s.ch
+}
+
+// Err 订阅结束的原因：ErrSlowConsumer、ErrBrokerClosed，主动取消时为 nil
+func (s *Subscription[T]) Err() error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.err
+}
+
+// Close 取消订阅，可重复调用
+func (s *Subscription[T]) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s, nil)
+}
@@ -0,0 +1,46 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func drain[T any](s *Subscription[T]) []T {
+	var out []T
+	for msg := range s.C() {
+		out = append(out, msg)
+	}
+	return out
+}
+
+func TestBroker_PublishAndEvict(t *testing.T) {
+	b := NewBroker[int](2)
+	fast, slow := b.Subscribe(), b.Subscribe()
+
+	b.Publish(1, 2)
+	assert.Equal(t, 1, <-fast.C())
+	assert.Equal(t, 2, <-fast.C())
+
+	// slow 未消费，缓冲区满后被剔除，不影响 fast
+	b.Publish(3)
+	assert.Equal(t, []int{1, 2}, drain(slow))
+	assert.ErrorIs(t, slow.Err(), ErrSlowConsumer)
+	assert.Equal(t, 3, <-fast.C())
+	assert.Equal(t, 1, b.Len())
+
+	fast.Close()
+	assert.NoError(t, fast.Err())
+	assert.Equal(t, 0, b.Len())
+}
+
+func TestBroker_Close(t *testing.T) {
+	b := NewBroker[string](0)
+	s := b.Subscribe()
+	b.Close()
+	b.Publish("ignored")
+
+	assert.Empty(t, drain(s))
+	assert.ErrorIs(t, s.Err(), ErrBrokerClosed)
+	assert.ErrorIs(t, b.Subscribe().Err(), ErrBrokerClosed)
+}
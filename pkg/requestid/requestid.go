@@ -0,0 +1,62 @@
+// Package requestid 请求 ID 的生成、校验与在 ctx 中的传递
+// 网关与 adhoc-server 接受调用方传入的 ID，没有时生成 ULID；同一个 ID 出现在响应头、错误响应与每行请求日志中
+package requestid
+
+import (
+	"context"
+
+	"github.com/oklog/ulid/v2"
+	"go.uber.org/zap"
+
+	"youlingserv/pkg/log"
+)
+
+const (
+	Header      = "X-Request-ID" // HTTP 请求头与响应头
+	MetadataKey = "x-request-id" // gRPC metadata 的 key，必须小写
+
+	maxLength = 128
+)
+
+type ctxKey struct{}
+
+// New 生成新的请求 ID（ULID，按时间有序）
+func New() string {
+	return ulid.Make().String()
+}
+
+// Valid 调用方传入的 ID 只接受 1~128 个字母、数字与 - _ . :，其余情况重新生成，避免日志注入与超长标签
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Ensure 传入的 ID 合法时原样返回，否则生成新的 ID
+func Ensure(id string) string {
+	if Valid(id) {
+		return id
+	}
+	return New()
+}
+
+// NewContext 把请求 ID 写入 ctx，并作为 request_id 字段附加到 log.FromContext 的日志中
+func NewContext(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, ctxKey{}, id)
+	return log.WithFields(ctx, zap.String(log.FieldRequestID, id))
+}
+
+// FromContext 取出请求 ID，没有时返回空字符串
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
@@ -0,0 +1,253 @@
+// Package tlsconfig 从磁盘加载 TLS 证书与 CA，文件变化后自动重新加载，生成 gRPC / Hertz 共用的 *tls.Config
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"youlingserv/pkg/config"
+	"youlingserv/pkg/log"
+)
+
+// 服务端校验客户端证书的方式
+const (
+	ClientAuthNone     = "none"     // 不要求客户端证书
+	ClientAuthOptional = "optional" // 客户端出示证书时校验
+	ClientAuthRequire  = "require"  // 必须出示可信的客户端证书（mTLS）
+)
+
+const defaultReloadInterval = 10 * time.Second
+
+// Reloader 持有当前的证书与 CA，定期检查文件的修改时间与大小，变化时重新加载并原子替换
+// 重新加载失败时保留当前版本；已建立的连接不受影响，新握手使用新证书
+type Reloader struct {
+	conf       config.TLSConfig
+	clientAuth string
+	current    atomic.Pointer[material]
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// material 一次加载的结果
+type material struct {
+	cert   *tls.Certificate // 未配置 cert_file 时为 nil（仅客户端）
+	pool   *x509.CertPool   // 未配置 ca_file 时为 nil
+	stamps map[string]fileStamp
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewReloader 加载证书并启动定期检查，首次加载失败直接返回错误
+func NewReloader(conf config.TLSConfig) (*Reloader, error) {
+	r := &Reloader{
+		conf:       conf,
+		clientAuth: conf.ClientAuth,
+		stop:       make(chan struct{}),
+	}
+	if (conf.CertFile == "") != (conf.KeyFile == "") {
+		return nil, errors.New("tls: cert_file and key_file must be set together")
+	}
+	switch r.clientAuth {
+	case "":
+		r.clientAuth = ClientAuthNone
+	case ClientAuthNone, ClientAuthOptional, ClientAuthRequire:
+	default:
+		return nil, fmt.Errorf("tls: unknown client_auth %q", conf.ClientAuth)
+	}
+	if r.conf.ReloadInterval <= 0 {
+		r.conf.ReloadInterval = defaultReloadInterval
+	}
+
+	m, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.current.Store(m)
+
+	r.wg.Add(1)
+	go r.reloadLoop()
+	return r, nil
+}
+
+// ServerConfig 服务端配置：证书与客户端 CA 都取当前版本
+// client_auth 为 optional / require 时必须配置 ca_file
+func (r *Reloader) ServerConfig() (*tls.Config, error) {
+	if r.conf.CertFile == "" {
+		return nil, errors.New("tls: cert_file is required for servers")
+	}
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return r.current.Load().cert, nil
+		},
+	}
+	if r.clientAuth == ClientAuthNone {
+		return cfg, nil
+	}
+	if r.conf.CAFile == "" {
+		return nil, fmt.Errorf("tls: ca_file is required when client_auth is %s", r.clientAuth)
+	}
+	// 标准库只支持固定的 ClientCAs，这里自行按当前 CA 校验，CA 轮换后无需重启
+	cfg.ClientAuth = tls.RequestClientCert
+	if r.clientAuth == ClientAuthRequire {
+		cfg.ClientAuth = tls.RequireAnyClientCert
+	}
+	cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return nil // require 时握手阶段已拒绝
+		}
+		return r.verify(cs.PeerCertificates, x509.ExtKeyUsageClientAuth, "")
+	}
+	return cfg, nil
+}
+
+// ClientConfig 客户端配置，配置了 cert_file 时出示客户端证书
+// server_name 为空时由调用方（如 gRPC 按 target 的主机名）填写 ServerName
+func (r *Reloader) ClientConfig() *tls.Config {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: r.conf.ServerName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if cert := r.current.Load().cert; cert != nil {
+				return cert, nil
+			}
+			return &tls.Certificate{}, nil
+		},
+	}
+	if r.conf.CAFile != "" {
+		// 同服务端：跳过按固定 RootCAs 的校验，改为在 VerifyConnection 中按当前 CA 与 ServerName 校验
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			if cs.ServerName == "" {
+				return errors.New("tls: server name is required to verify the server certificate")
+			}
+			return r.verify(cs.PeerCertificates, x509.ExtKeyUsageServerAuth, cs.ServerName)
+		}
+	}
+	return cfg
+}
+
+// verify 按当前 CA 校验对端证书链，dnsName 为空时不校验主机名（服务端校验客户端证书）
+func (r *Reloader) verify(certs []*x509.Certificate, usage x509.ExtKeyUsage, dnsName string) error {
+	if len(certs) == 0 {
+		return errors.New("tls: peer presented no certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         r.current.Load().pool,
+		Intermediates: intermediates,
+		DNSName:       dnsName,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	})
+	return err
+}
+
+// Reload 立即检查文件，有变化时重新加载；返回是否替换了当前版本
+func (r *Reloader) Reload() (bool, error) {
+	if !r.changed() {
+		return false, nil
+	}
+	m, err := r.load()
+	if err != nil {
+		return false, err
+	}
+	r.current.Store(m)
+	return true, nil
+}
+
+// Close 停止定期检查，可重复调用
+func (r *Reloader) Close() {
+	r.once.Do(func() {
+		close(r.stop)
+		r.wg.Wait()
+	})
+}
+
+func (r *Reloader) reloadLoop() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.conf.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			reloaded, err := r.Reload()
+			if err != nil {
+				log.GetLogger().Warn("Failed to reload TLS certificates, keeping previous version", zap.Error(err))
+			} else if reloaded {
+				log.GetLogger().Info("Reloaded TLS certificates")
+			}
+		}
+	}
+}
+
+// changed 任一文件的修改时间或大小与当前版本不同；stat 失败视为未变化，等待下次检查
+func (r *Reloader) changed() bool {
+	for path, old := range r.current.Load().stamps {
+		stamp, err := stat(path)
+		if err == nil && stamp != old {
+			return true
+		}
+	}
+	return false
+}
+
+// load 读取证书、私钥与 CA；先记录文件状态再读取，读取期间发生的变化会在下次检查时重新加载
+func (r *Reloader) load() (*material, error) {
+	m := &material{stamps: make(map[string]fileStamp)}
+	for _, path := range []string{r.conf.CertFile, r.conf.KeyFile, r.conf.CAFile} {
+		if path == "" {
+			continue
+		}
+		stamp, err := stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("tls: %w", err)
+		}
+		m.stamps[path] = stamp
+	}
+
+	if r.conf.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(r.conf.CertFile, r.conf.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: load key pair %s: %w", r.conf.CertFile, err)
+		}
+		m.cert = &cert
+	}
+	if r.conf.CAFile != "" {
+		data, err := os.ReadFile(r.conf.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: %w", err)
+		}
+		m.pool = x509.NewCertPool()
+		if !m.pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("tls: no certificates found in %s", r.conf.CAFile)
+		}
+	}
+	return m, nil
+}
+
+func stat(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
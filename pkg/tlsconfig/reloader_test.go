@@ -0,0 +1,178 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"youlingserv/pkg/config"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue 签发叶子证书，返回证书与私钥的 PEM
+func (ca *testCA) issue(t *testing.T, tmpl *x509.Certificate) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl.SerialNumber = big.NewInt(time.Now().UnixNano())
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeFiles 写入证书、私钥与 CA，并把修改时间推后，确保与上一版本的文件状态不同
+func writeFiles(t *testing.T, dir, prefix string, cert, key, ca []byte, version int) config.TLSConfig {
+	t.Helper()
+	conf := config.TLSConfig{
+		Enabled:  true,
+		CertFile: filepath.Join(dir, prefix+".crt"),
+		KeyFile:  filepath.Join(dir, prefix+".key"),
+		CAFile:   filepath.Join(dir, prefix+"-ca.crt"),
+	}
+	mtime := time.Now().Add(time.Duration(version) * time.Minute)
+	for path, data := range map[string][]byte{conf.CertFile: cert, conf.KeyFile: key, conf.CAFile: ca} {
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+	}
+	return conf
+}
+
+// handshake 建立一次 TLS 连接，返回服务端看到的客户端证书
+func handshake(t *testing.T, serverConf, clientConf *tls.Config) (*x509.Certificate, error) {
+	t.Helper()
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", serverConf)
+	require.NoError(t, err)
+	defer lis.Close()
+
+	peerCh := make(chan *x509.Certificate, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			peerCh <- nil
+			return
+		}
+		defer conn.Close()
+		tlsConn := conn.(*tls.Conn)
+		if tlsConn.Handshake() != nil || len(tlsConn.ConnectionState().PeerCertificates) == 0 {
+			peerCh <- nil
+			return
+		}
+		peerCh <- tlsConn.ConnectionState().PeerCertificates[0]
+	}()
+
+	clientConf = clientConf.Clone()
+	clientConf.ServerName = "localhost"
+	conn, err := tls.Dial("tcp", lis.Addr().String(), clientConf)
+	if err == nil {
+		// TLS 1.3 中服务端对客户端证书的拒绝在客户端首次读取时才会暴露；握手成功时服务端直接关闭连接
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		if _, err = conn.Read(make([]byte, 1)); errors.Is(err, io.EOF) {
+			err = nil
+		}
+		conn.Close()
+	}
+	return <-peerCh, err
+}
+
+func TestReloader_MutualTLSAndRotation(t *testing.T) {
+	dir := t.TempDir()
+	spiffeID, _ := url.Parse("spiffe://youling/api-gateway")
+
+	ca := newTestCA(t, "ca-1")
+	serverCert, serverKey := ca.issue(t, &x509.Certificate{DNSNames: []string{"localhost"}, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}})
+	clientCert, clientKey := ca.issue(t, &x509.Certificate{URIs: []*url.URL{spiffeID}, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+
+	serverTLS := writeFiles(t, dir, "server", serverCert, serverKey, ca.pem, 0)
+	serverTLS.ClientAuth = ClientAuthRequire
+	server, err := NewReloader(serverTLS)
+	require.NoError(t, err)
+	defer server.Close()
+	serverConf, err := server.ServerConfig()
+	require.NoError(t, err)
+
+	client, err := NewReloader(writeFiles(t, dir, "client", clientCert, clientKey, ca.pem, 0))
+	require.NoError(t, err)
+	defer client.Close()
+
+	peerCert, err := handshake(t, serverConf, client.ClientConfig())
+	require.NoError(t, err)
+	require.NotNil(t, peerCert)
+	assert.Equal(t, spiffeID.String(), peerCert.URIs[0].String())
+
+	// 不出示客户端证书时拒绝
+	anonymous, err := NewReloader(config.TLSConfig{CAFile: filepath.Join(dir, "client-ca.crt")})
+	require.NoError(t, err)
+	defer anonymous.Close()
+	_, err = handshake(t, serverConf, anonymous.ClientConfig())
+	assert.Error(t, err)
+
+	// 服务端换成新 CA 签发的证书后，未重新加载 CA 的客户端拒绝连接
+	ca2 := newTestCA(t, "ca-2")
+	serverCert, serverKey = ca2.issue(t, &x509.Certificate{DNSNames: []string{"localhost"}, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}})
+	writeFiles(t, dir, "server", serverCert, serverKey, append(ca.pem, ca2.pem...), 1)
+	reloaded, err := server.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	_, err = handshake(t, serverConf, client.ClientConfig())
+	assert.Error(t, err)
+
+	// 客户端加载新 CA 后无需重建配置即可连接
+	writeFiles(t, dir, "client", clientCert, clientKey, append(ca.pem, ca2.pem...), 1)
+	reloaded, err = client.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	_, err = handshake(t, serverConf, client.ClientConfig())
+	require.NoError(t, err)
+
+	// 损坏的文件不会替换当前版本
+	require.NoError(t, os.WriteFile(serverTLS.CertFile, []byte("garbage"), 0o600))
+	_, err = server.Reload()
+	assert.Error(t, err)
+	_, err = handshake(t, serverConf, client.ClientConfig())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,36 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+
+	"youlingserv/pkg/config"
+)
+
+// NewServerConfig conf.Enabled 为 false 时返回 nil（明文）；cleanup 停止证书重新加载
+func NewServerConfig(conf config.TLSConfig) (*tls.Config, func(), error) {
+	if !conf.Enabled {
+		return nil, func() {}, nil
+	}
+	r, err := NewReloader(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg, err := r.ServerConfig()
+	if err != nil {
+		r.Close()
+		return nil, nil, err
+	}
+	return cfg, r.Close, nil
+}
+
+// NewClientConfig conf.Enabled 为 false 时返回 nil（明文）；cleanup 停止证书重新加载
+func NewClientConfig(conf config.TLSConfig) (*tls.Config, func(), error) {
+	if !conf.Enabled {
+		return nil, func() {}, nil
+	}
+	r, err := NewReloader(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.ClientConfig(), r.Close, nil
+}
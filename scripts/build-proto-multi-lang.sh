@@ -6,6 +6,7 @@ set -e
 # 配置文件路径
 CONFIG_FILE="${CONFIG_FILE:-gen.config.yaml}"
 PROTO_DIR="${PROTO_DIR:-api}"
+THIRD_PARTY_DIR="${THIRD_PARTY_DIR:-third_party}"
 OUTPUT_BASE="${OUTPUT_BASE:-gen}"
 
 # 颜色输出
@@ -86,6 +87,7 @@ generate_go() {
     
     protoc \
         --proto_path="$PROTO_DIR" \
+        --proto_path="$THIRD_PARTY_DIR" \
         --go_out="$output_dir" \
         --go_opt=paths=source_relative \
         --go-grpc_out="$output_dir" \
@@ -126,6 +128,7 @@ generate_python() {
     
     python3 -m grpc_tools.protoc \
         --proto_path="$PROTO_DIR" \
+        --proto_path="$THIRD_PARTY_DIR" \
         --python_out="$output_dir" \
         --grpc_python_out="$output_dir" \
         --pyi_out="$output_dir" \
@@ -163,6 +166,7 @@ generate_typescript() {
     
     protoc \
         --proto_path="$PROTO_DIR" \
+        --proto_path="$THIRD_PARTY_DIR" \
         --plugin=protoc-gen-ts=$(which protoc-gen-ts) \
         --ts_out="$output_dir" \
         $(find "$PROTO_DIR" -name "*.proto")
@@ -189,6 +193,7 @@ generate_java() {
     
     protoc \
         --proto_path="$PROTO_DIR" \
+        --proto_path="$THIRD_PARTY_DIR" \
         --java_out="$output_dir" \
         $(find "$PROTO_DIR" -name "*.proto")
     
@@ -212,6 +217,7 @@ generate_cpp() {
     
     protoc \
         --proto_path="$PROTO_DIR" \
+        --proto_path="$THIRD_PARTY_DIR" \
         --cpp_out="$output_dir" \
         --grpc_out="$output_dir" \
         --plugin=protoc-gen-grpc=$(which grpc_cpp_plugin) \
@@ -250,6 +256,7 @@ generate_docs() {
     
     protoc \
         --proto_path="$PROTO_DIR" \
+        --proto_path="$THIRD_PARTY_DIR" \
         --doc_out="$output_dir" \
         --doc_opt=html,index.html \
         $(find "$PROTO_DIR" -name "*.proto")
@@ -9,6 +9,7 @@ REM 配置变量
 set "PROTO_DIR=api"
 set "OUTPUT_DIR=gen"
 set "PROTO_PATH=api"
+set "THIRD_PARTY_PATH=third_party"
 
 REM 临时文件
 set "TEMP_FILES=%TEMP%\proto_files_%RANDOM%.txt"
@@ -143,6 +144,7 @@ call :log_info "开始编译 proto 文件..."
 REM 构建 protoc 命令
 set "PROTOC_CMD=protoc"
 set "PROTOC_CMD=!PROTOC_CMD! --proto_path=%PROTO_PATH%"
+set "PROTOC_CMD=!PROTOC_CMD! --proto_path=%THIRD_PARTY_PATH%"
 set "PROTOC_CMD=!PROTOC_CMD! --go_out=%OUTPUT_DIR%"
 set "PROTOC_CMD=!PROTOC_CMD! --go_opt=paths=source_relative"
 set "PROTOC_CMD=!PROTOC_CMD! --go-grpc_out=%OUTPUT_DIR%"
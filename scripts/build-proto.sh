@@ -9,6 +9,7 @@ set -e  # 遇到错误立即退出
 PROTO_DIR="api"
 OUTPUT_DIR="gen"
 PROTO_PATH="api"
+THIRD_PARTY_PATH="third_party"  # 第三方 proto（如 google/api/annotations.proto），仅作为 import 路径
 
 # 颜色输出
 RED='\033[0;31m'
@@ -87,6 +88,7 @@ compile_protos() {
     # 构建 protoc 命令
     PROTOC_CMD="protoc"
     PROTOC_CMD="$PROTOC_CMD --proto_path=$PROTO_PATH"
+    PROTOC_CMD="$PROTOC_CMD --proto_path=$THIRD_PARTY_PATH"
     PROTOC_CMD="$PROTOC_CMD --go_out=$OUTPUT_DIR"
     PROTOC_CMD="$PROTOC_CMD --go_opt=paths=source_relative"
     PROTOC_CMD="$PROTOC_CMD --go-grpc_out=$OUTPUT_DIR"
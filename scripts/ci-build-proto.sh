@@ -189,6 +189,7 @@ generate_docs() {
     
     find api -name "*.proto" -exec protoc \
         --proto_path=api \
+        --proto_path=third_party \
         --doc_out=docs/api \
         --doc_opt=html,index.html \
         {} +